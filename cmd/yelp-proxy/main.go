@@ -0,0 +1,94 @@
+// Command yelp-proxy serves a simplified REST facade over the Yelp Fusion API,
+// so frontend applications can query Yelp without holding the API key. See
+// package gateway for the endpoints.
+//
+// The client is configured from the file given with -config, or from the
+// YELP_* environment variables otherwise.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ivancevich/go-yelp/gateway"
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	configPath := flag.String("config", "", "path of the configuration file")
+	cacheTTL := flag.Duration("cache-ttl", 10*time.Minute, "how long responses are cached, 0 to disable")
+	rate := flag.Float64("rate", 5, "requests per second allowed to each caller, 0 to disable")
+	burst := flag.Int("burst", 20, "burst of requests allowed to each caller")
+	origin := flag.String("allow-origin", "", "value of Access-Control-Allow-Origin")
+	printSpec := flag.Bool("openapi", false, "print the OpenAPI document of the endpoints and exit")
+	flag.Parse()
+
+	if *printSpec {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(gateway.OpenAPI()); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	opts := []yelp.Option{}
+	if *cacheTTL > 0 {
+		opts = append(opts, yelp.WithCache(yelp.NewMemoryCache(), *cacheTTL,
+			yelp.EndpointSearch, yelp.EndpointBusiness, yelp.EndpointReviews))
+	}
+	client, err := yelp.NewFromConfig(cfg, opts...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	srv := &http.Server{
+		Addr: *addr,
+		Handler: gateway.New(client, gateway.Options{
+			RatePerSecond: *rate,
+			Burst:         *burst,
+			AllowedOrigin: *origin,
+		}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+		client.Close(shutdownCtx)
+	}()
+
+	log.Printf("yelp-proxy listening on %s", *addr)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+	<-done
+}
+
+// loadConfig reads the configuration file at path, or the environment when
+// path is empty.
+func loadConfig(path string) (yelp.Config, error) {
+	if path == "" {
+		return yelp.ConfigFromEnv()
+	}
+	return yelp.LoadConfig(path)
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+// Keys read by yelp browse.
+const (
+	keyUp    = "\x1b[A"
+	keyDown  = "\x1b[B"
+	keyEnter = "\n"
+)
+
+// runBrowse searches businesses and lets the user move through the results
+// with the arrow keys, showing the one selected in a detail pane. The results
+// are printed as a list when stdin is not a terminal or -format is set.
+func runBrowse(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("browse", flag.ExitOnError)
+	newClient := clientFlags(fs)
+	location := fs.String("location", "", "location to search")
+	term := fs.String("term", "", "search term")
+	categories := fs.String("categories", "", "comma separated category aliases")
+	limit := fs.Int64("limit", 20, "number of results, up to 50")
+	format := formatFlag(fs, "business instead of browsing, e.g. '{{.Name}} ({{.Rating}}★) {{.Location.Address1}}'")
+	fs.Parse(args)
+
+	if *location == "" {
+		return usageError("-location is required")
+	}
+	tmpl, err := format()
+	if err != nil {
+		return err
+	}
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close(context.Background())
+
+	so := yelp.SearchOptions{Location: location, Limit: limit}
+	if *term != "" {
+		so.Term = term
+	}
+	if *categories != "" {
+		so.Categories = categories
+	}
+	results, err := client.Search(ctx, so)
+	if err != nil {
+		return err
+	}
+	if len(results.Businesses) == 0 {
+		return errNoResults
+	}
+
+	restore, err := makeRaw(os.Stdin)
+	if err != nil || tmpl != nil {
+		for _, b := range results.Businesses {
+			if tmpl == nil {
+				fmt.Println(listLine(b))
+			} else if err := writeFormatted(os.Stdout, tmpl, b); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	defer restore()
+
+	keys := make(chan string)
+	go func() {
+		buf := make([]byte, 8)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if err != nil {
+				close(keys)
+				return
+			}
+			keys <- string(buf[:n])
+		}
+	}()
+
+	br := &browser{businesses: results.Businesses, details: map[string]bool{}}
+	for {
+		br.render()
+		var key string
+		var ok bool
+		select {
+		case key, ok = <-keys:
+		case <-ctx.Done():
+		}
+		if !ok || key == "q" {
+			fmt.Print("\x1b[H\x1b[2J")
+			return nil
+		}
+		switch key {
+		case keyUp, "k":
+			if br.selected > 0 {
+				br.selected--
+			}
+		case keyDown, "j":
+			if br.selected < len(br.businesses)-1 {
+				br.selected++
+			}
+		case keyEnter, "\r":
+			b := br.businesses[br.selected]
+			if br.details[b.ID] {
+				continue
+			}
+			br.status = "loading " + b.Name + "..."
+			br.render()
+			d, err := client.BusinessByID(ctx, b.ID, yelp.BusinessOptions{})
+			if err != nil {
+				br.status = err.Error()
+				continue
+			}
+			d.Distance = b.Distance
+			br.businesses[br.selected], br.details[b.ID], br.status = d, true, ""
+		}
+	}
+}
+
+// browser is the state of yelp browse.
+type browser struct {
+	businesses []yelp.Business
+	selected   int
+	details    map[string]bool
+	status     string
+}
+
+// detailRows is the height of the detail pane.
+const detailRows = 9
+
+// render draws the list of businesses and the detail pane of the selected one.
+func (br *browser) render() {
+	rows, cols := termSize(os.Stdout)
+	height := rows - detailRows - 2
+	if height < 1 {
+		height = 1
+	}
+	first := 0
+	if br.selected >= height {
+		first = br.selected - height + 1
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\x1b[H\x1b[2J")
+	for i := first; i < len(br.businesses) && i < first+height; i++ {
+		line := clip("  "+listLine(br.businesses[i]), cols)
+		if i == br.selected {
+			line = "\x1b[7m" + line + "\x1b[0m"
+		}
+		sb.WriteString(line + "\n")
+	}
+	sb.WriteString(strings.Repeat("─", cols) + "\n")
+	for _, l := range br.detail(br.businesses[br.selected]) {
+		sb.WriteString(clip(l, cols) + "\n")
+	}
+	status := br.status
+	if status == "" {
+		status = "↑/↓ move · enter details · q quit"
+	}
+	sb.WriteString("\x1b[" + fmt.Sprint(rows) + ";1H" + clip(status, cols))
+	fmt.Print(sb.String())
+}
+
+// detail returns the lines of the detail pane of b.
+func (br *browser) detail(b yelp.Business) []string {
+	lines := []string{
+		b.Name,
+		fmt.Sprintf("%.1f★  %s reviews  %s", b.Rating, b.ReviewCountFormatted(""), b.Price),
+		categoryTitles(b),
+		strings.Join(b.Location.DisplayAddress, ", "),
+		b.DisplayPhone,
+	}
+	if b.Distance > 0 {
+		lines = append(lines, b.DistanceFormatted("")+" away")
+	}
+	if br.details[b.ID] {
+		lines = append(lines, hoursLine(b), b.URL)
+	} else {
+		lines = append(lines, "press enter for hours and details")
+	}
+	return lines
+}
+
+// listLine returns the line of b in the list of results.
+func listLine(b yelp.Business) string {
+	return fmt.Sprintf("%-40s %.1f★ %5d  %s", b.Name, b.Rating, b.ReviewCount, b.Location.City)
+}
+
+// categoryTitles returns the titles of the categories of b.
+func categoryTitles(b yelp.Business) string {
+	titles := make([]string, len(b.Categories))
+	for i, c := range b.Categories {
+		titles[i] = c.Title
+	}
+	return strings.Join(titles, ", ")
+}
+
+// hoursLine returns the weekly hours of b on a line.
+func hoursLine(b yelp.Business) string {
+	days := []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+	ranges := []string{}
+	for _, oh := range b.RegularHours() {
+		if oh.Day >= 0 && oh.Day < len(days) {
+			ranges = append(ranges, fmt.Sprintf("%s %s-%s", days[oh.Day], oh.Start, oh.End))
+		}
+	}
+	if len(ranges) == 0 {
+		return "no hours"
+	}
+	return strings.Join(ranges, ", ")
+}
+
+// clip cuts s to n runes.
+func clip(s string, n int) string {
+	r := []rune(s)
+	if len(r) > n {
+		return string(r[:n])
+	}
+	return s
+}
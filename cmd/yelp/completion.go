@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// completion is registered in init as its scripts list the commands.
+func init() {
+	commands = append(commands, command{"completion", "print the shell completion script for bash, zsh or fish", runCompletion})
+}
+
+// runCompletion prints the completion script for the shell given as argument,
+// e.g. for bash
+//
+//	source <(yelp completion bash)
+//
+// Commands are completed from the script, flags by asking "yelp <command> -h"
+// and profiles by asking "yelp configure -list".
+func runCompletion(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return usageError("usage: yelp completion bash|zsh|fish")
+	}
+	names := make([]string, len(commands))
+	for i, cmd := range commands {
+		names[i] = cmd.name
+	}
+
+	switch args[0] {
+	case "bash":
+		fmt.Printf(bashCompletion, strings.Join(names, " "))
+	case "zsh":
+		fmt.Print("autoload -U +X bashcompinit && bashcompinit\n")
+		fmt.Printf(bashCompletion, strings.Join(names, " "))
+	case "fish":
+		for _, cmd := range commands {
+			fmt.Printf("complete -c yelp -f -n __fish_use_subcommand -a %s -d '%s'\n",
+				cmd.name, strings.ReplaceAll(cmd.usage, "'", `\'`))
+		}
+		fmt.Print(fishCompletion)
+	default:
+		return usageError(fmt.Sprintf("unknown shell %q, want bash, zsh or fish", args[0]))
+	}
+	return nil
+}
+
+const bashCompletion = `_yelp() {
+	local cur=${COMP_WORDS[COMP_CWORD]} prev=${COMP_WORDS[COMP_CWORD-1]}
+	if [ "$COMP_CWORD" -eq 1 ]; then
+		COMPREPLY=($(compgen -W "%s" -- "$cur"))
+	elif [ "$prev" = "-profile" ] || [ "$prev" = "--profile" ]; then
+		COMPREPLY=($(compgen -W "$(yelp configure -list 2>/dev/null | cut -c3-)" -- "$cur"))
+	elif [[ $cur == -* ]]; then
+		COMPREPLY=($(compgen -W "$(yelp "${COMP_WORDS[1]}" -h 2>&1 | sed -n 's/^  \(-[^ ]*\).*/\1/p')" -- "$cur"))
+	else
+		COMPREPLY=($(compgen -f -- "$cur"))
+	fi
+}
+complete -o filenames -F _yelp yelp
+`
+
+const fishCompletion = `complete -c yelp -n 'not __fish_use_subcommand' -a '(yelp (commandline -opc)[2] -h 2>&1 | string match -r "^  -\S+" | string trim)'
+complete -c yelp -f -n 'test (commandline -opc)[-1] = -profile' -a '(yelp configure -list 2>/dev/null | string sub -s 3)'
+`
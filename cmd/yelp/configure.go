@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+// setting is a value asked for by yelp configure.
+type setting struct {
+	key    string
+	prompt string
+	secret bool
+	value  func(yelp.Config) string
+}
+
+// settings are the values asked for by yelp configure, other values are
+// edited in the file.
+var settings = []setting{
+	{"api_key", "API key", true, func(cfg yelp.Config) string { return cfg.APIKey }},
+	{"api_host", "API host", false, func(cfg yelp.Config) string { return cfg.APIHost }},
+	{"locale", "Locale", false, func(cfg yelp.Config) string { return cfg.Locale }},
+}
+
+// runConfigure sets up the configuration of a profile interactively, or
+// lists, imports or exports profiles.
+func runConfigure(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("configure", flag.ExitOnError)
+	profile := fs.String("profile", os.Getenv(yelp.EnvProfile), "name of the profile, default when empty")
+	list := fs.Bool("list", false, "list the profiles")
+	importPath := fs.String("import", "", "YAML configuration file to use as the profile")
+	export := fs.Bool("export", false, "write the configuration of the profile to stdout")
+	format := formatFlag(fs, "profile listed, with .Name and .Selected")
+	fs.Parse(args)
+
+	if *profile == "" {
+		*profile = yelp.DefaultProfile
+	}
+	if *list {
+		tmpl, err := format()
+		if err != nil {
+			return err
+		}
+		names, err := yelp.Profiles()
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			if tmpl != nil {
+				p := struct {
+					Name     string
+					Selected bool
+				}{name, name == *profile}
+				if err := writeFormatted(os.Stdout, tmpl, p); err != nil {
+					return err
+				}
+				continue
+			}
+			mark := " "
+			if name == *profile {
+				mark = "*"
+			}
+			fmt.Printf("%s %s\n", mark, name)
+		}
+		return nil
+	}
+
+	path, err := yelp.ProfileConfigPath(*profile)
+	if err != nil {
+		return err
+	}
+	switch {
+	case *export:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(data)
+		return err
+	case *importPath != "":
+		if strings.EqualFold(filepath.Ext(*importPath), ".json") {
+			return usageError("-import takes a YAML file")
+		}
+		if _, err := yelp.LoadConfig(*importPath); err != nil {
+			return err
+		}
+		data, err := os.ReadFile(*importPath)
+		if err != nil {
+			return err
+		}
+		return writeProfile(path, data)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	cfg := yelp.Config{}
+	if len(data) > 0 {
+		if cfg, err = yelp.LoadConfig(path); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Configuring profile %s in %s, press enter to keep a value.\n", *profile, path)
+	in := bufio.NewScanner(os.Stdin)
+	values := map[string]string{}
+	for _, s := range settings {
+		current := s.value(cfg)
+		shown := current
+		if s.secret && len(current) > 4 {
+			shown = strings.Repeat("*", 8) + current[len(current)-4:]
+		}
+		fmt.Fprintf(os.Stderr, "%s [%s]: ", s.prompt, shown)
+		if !in.Scan() {
+			if err := in.Err(); err != nil {
+				return err
+			}
+			break
+		}
+		if v := strings.TrimSpace(in.Text()); v != "" {
+			values[s.key] = v
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return writeProfile(path, setYAMLValues(data, values))
+}
+
+// writeProfile writes the configuration data of a profile to path. The file
+// is only readable by the user as it holds the API key.
+func writeProfile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// setYAMLValues sets the top-level scalar keys of the YAML document data to
+// values, keeping the other lines and comments, and appends the keys it does
+// not have.
+func setYAMLValues(data []byte, values map[string]string) []byte {
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	if len(data) == 0 {
+		lines = nil
+	}
+	done := map[string]bool{}
+	for i, l := range lines {
+		key, _, ok := strings.Cut(l, ":")
+		if !ok || key != strings.TrimSpace(key) {
+			continue
+		}
+		if v, ok := values[key]; ok {
+			lines[i] = key + ": " + strconv.Quote(v)
+			done[key] = true
+		}
+	}
+	for _, s := range settings {
+		if v, ok := values[s.key]; ok && !done[s.key] {
+			lines = append(lines, s.key+": "+strconv.Quote(v))
+		}
+	}
+	return []byte(strings.Join(lines, "\n") + "\n")
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+// runDiff prints the businesses added, removed and changed between two
+// snapshot files.
+func runDiff(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "print the diff as JSON")
+	format := formatFlag(fs, "change, with .Change added, removed or changed")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: yelp diff [flags] old.json new.json")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		return usageError("usage: yelp diff [flags] old.json new.json")
+	}
+	tmpl, err := format()
+	if err != nil {
+		return err
+	}
+	snaps := [2]yelp.Snapshot{}
+	for i := range snaps {
+		s, err := readSnapshot(fs.Arg(i))
+		if err != nil {
+			return err
+		}
+		if s == nil {
+			return fmt.Errorf("%s: %w", fs.Arg(i), os.ErrNotExist)
+		}
+		snaps[i] = *s
+	}
+
+	if *asJSON {
+		return yelp.DiffSnapshots(snaps[0], snaps[1]).WriteJSON(os.Stdout)
+	}
+	return printChanges(snaps[0], snaps[1], tmpl)
+}
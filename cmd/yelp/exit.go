@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+// Exit codes of yelp. They are stable, so scripts can rely on them.
+const (
+	exitOK           = 0
+	exitError        = 1
+	exitUsage        = 2
+	exitUnauthorized = 3
+	exitRateLimited  = 4
+	exitQuota        = 5
+	exitNotFound     = 6
+	exitInvalid      = 7
+	exitUnavailable  = 8
+	exitCanceled     = 130
+)
+
+// classExitCodes maps the classes of errors to their exit code, other
+// classes exit with exitError.
+var classExitCodes = map[yelp.ErrorClass]int{
+	yelp.ErrorClassUnauthorized:   exitUnauthorized,
+	yelp.ErrorClassRateLimited:    exitRateLimited,
+	yelp.ErrorClassQuota:          exitQuota,
+	yelp.ErrorClassNotFound:       exitNotFound,
+	yelp.ErrorClassInvalidRequest: exitInvalid,
+	yelp.ErrorClassServer:         exitUnavailable,
+	yelp.ErrorClassNetwork:        exitUnavailable,
+	yelp.ErrorClassTimeout:        exitUnavailable,
+	yelp.ErrorClassCanceled:       exitCanceled,
+}
+
+// usageError is an error in the flags or arguments of a command.
+type usageError string
+
+func (e usageError) Error() string { return string(e) }
+
+// errNoResults is returned by commands finding nothing.
+var errNoResults = errors.New("no businesses found")
+
+// errorClass returns the class of err, "usage" for usage errors.
+func errorClass(err error) yelp.ErrorClass {
+	switch {
+	case errors.As(err, new(usageError)):
+		return "usage"
+	case errors.Is(err, errNoResults):
+		return yelp.ErrorClassNotFound
+	}
+	return yelp.ClassifyError(err)
+}
+
+// exitCode returns the exit code of a command failing with err.
+func exitCode(err error) int {
+	class := errorClass(err)
+	switch {
+	case class == yelp.ErrorClassNone:
+		return exitOK
+	case class == "usage":
+		return exitUsage
+	}
+	if code, ok := classExitCodes[class]; ok {
+		return code
+	}
+	return exitError
+}
+
+// jsonError is the error printed with -json-errors.
+type jsonError struct {
+	Command   string `json:"command"`
+	Error     string `json:"error"`
+	Class     string `json:"class"`
+	ExitCode  int    `json:"exit_code"`
+	Status    int    `json:"status,omitempty"`
+	Code      string `json:"code,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// exit prints the error of the command on stderr, as JSON when asJSON, and
+// exits with its exit code.
+func exit(command string, err error, asJSON bool) {
+	code := exitCode(err)
+	if !asJSON {
+		fmt.Fprintf(os.Stderr, "yelp %s: %v\n", command, err)
+		os.Exit(code)
+	}
+
+	je := jsonError{
+		Command:  command,
+		Error:    err.Error(),
+		Class:    string(errorClass(err)),
+		ExitCode: code,
+	}
+	var apiErr *yelp.APIError
+	if errors.As(err, &apiErr) {
+		je.Status, je.Code, je.RequestID = apiErr.StatusCode, apiErr.Code, apiErr.RequestID
+	}
+	json.NewEncoder(os.Stderr).Encode(je)
+	os.Exit(code)
+}
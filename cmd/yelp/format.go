@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+)
+
+// formatFuncs are the functions available to -format templates.
+var formatFuncs = template.FuncMap{
+	"join": strings.Join,
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// formatFlag registers the -format flag on fs and returns a function parsing
+// its Go template once fs is parsed, nil when the flag is not set, e.g.
+//
+//	-format '{{.Name}} ({{.Rating}}★) {{.Location.Address1}}'
+func formatFlag(fs *flag.FlagSet, of string) func() (*template.Template, error) {
+	format := fs.String("format", "", "Go template printing each "+of)
+	return func() (*template.Template, error) {
+		if *format == "" {
+			return nil, nil
+		}
+		tmpl, err := template.New("format").Funcs(formatFuncs).Parse(*format)
+		if err != nil {
+			return nil, usageError(fmt.Sprintf("invalid -format: %v", err))
+		}
+		return tmpl, nil
+	}
+}
+
+// writeFormatted writes v formatted by tmpl to w, on a line of its own.
+func writeFormatted(w io.Writer, tmpl *template.Template, v interface{}) error {
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, v); err != nil {
+		return err
+	}
+	s := sb.String()
+	if !strings.HasSuffix(s, "\n") {
+		s += "\n"
+	}
+	_, err := io.WriteString(w, s)
+	return err
+}
@@ -0,0 +1,129 @@
+// Command yelp queries the Yelp Fusion API from the command line.
+//
+// Usage:
+//
+//	yelp [-json-errors] <command> [flags]
+//
+// Run "yelp <command> -h" for the flags of a command. The client is
+// configured from the file given with -config, from the profile given with
+// -profile or YELP_PROFILE, see "yelp configure", from the default profile
+// when it exists, or from the YELP_* environment variables otherwise.
+//
+// yelp exits with a status telling why it failed, and prints the error on
+// stderr, as a JSON object with -json-errors:
+//
+//	0    success
+//	1    other error
+//	2    invalid command, flags or arguments
+//	3    missing, invalid or unauthorized API key
+//	4    rate limited, try again shortly
+//	5    daily quota used up, try again tomorrow
+//	6    business or resource not found
+//	7    request rejected by Yelp as invalid
+//	8    Yelp unavailable: server, network or timeout error
+//	130  interrupted
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+// command is a subcommand of yelp.
+type command struct {
+	name  string
+	usage string
+	run   func(ctx context.Context, args []string) error
+}
+
+// commands are the subcommands of yelp.
+var commands = []command{
+	{"snapshot", "crawl an area and record the changes since the last run", runSnapshot},
+	{"diff", "print the changes between two snapshots", runDiff},
+	{"match", "find the Yelp businesses of the locations of a CSV file", runMatch},
+	{"warm", "fill the configured cache with the details of businesses", runWarm},
+	{"validate-ids", "check which stored business ids are still open", runValidateIDs},
+	{"configure", "set up, list, import or export configuration profiles", runConfigure},
+	{"browse", "search businesses and browse the results in the terminal", runBrowse},
+}
+
+func main() {
+	jsonErrors := flag.Bool("json-errors", false, "print errors as JSON objects")
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() < 1 {
+		usage()
+		os.Exit(exitUsage)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	for _, cmd := range commands {
+		if cmd.name == flag.Arg(0) {
+			if err := cmd.run(ctx, flag.Args()[1:]); err != nil {
+				stop()
+				exit(cmd.name, err, *jsonErrors)
+			}
+			return
+		}
+	}
+	usage()
+	os.Exit(exitUsage)
+}
+
+// usage prints the available commands.
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: yelp [-json-errors] <command> [flags]")
+	fmt.Fprintln(os.Stderr, "\ncommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-13s %s\n", cmd.name, cmd.usage)
+	}
+}
+
+// clientFlags registers the flags configuring the client on fs and returns a
+// function making the client once fs is parsed.
+func clientFlags(fs *flag.FlagSet) func() (yelp.Client, error) {
+	config := configFlags(fs)
+	return func() (yelp.Client, error) {
+		cfg, err := config()
+		if err != nil {
+			return nil, err
+		}
+		return yelp.NewFromConfig(cfg)
+	}
+}
+
+// configFlags registers the flags locating the configuration on fs and
+// returns a function loading it once fs is parsed.
+func configFlags(fs *flag.FlagSet) func() (yelp.Config, error) {
+	configPath := fs.String("config", "", "path of the configuration file")
+	profile := fs.String("profile", os.Getenv(yelp.EnvProfile), "name of the configuration profile, see yelp configure")
+	return func() (yelp.Config, error) {
+		return loadConfig(*configPath, *profile)
+	}
+}
+
+// loadConfig reads the configuration file at path, or the one of profile,
+// or the one of the default profile when both are empty. The environment is
+// read when there is no default profile.
+func loadConfig(path, profile string) (yelp.Config, error) {
+	switch {
+	case path != "":
+		return yelp.LoadConfig(path)
+	case profile != "":
+		return yelp.LoadProfile(profile)
+	}
+	if def, err := yelp.DefaultConfigPath(); err == nil {
+		if _, err := os.Stat(def); err == nil {
+			return yelp.LoadProfile(yelp.DefaultProfile)
+		}
+	}
+	return yelp.ConfigFromEnv()
+}
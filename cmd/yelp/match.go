@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+// runMatch finds the Yelp businesses of the locations of a CSV file.
+func runMatch(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("match", flag.ExitOnError)
+	newClient := clientFlags(fs)
+	in := fs.String("in", "", "CSV file with name,address,city,state,zip,phone columns, - for stdin")
+	out := fs.String("out", "-", "CSV file to write the matches to, - for stdout")
+	minConfidence := fs.Float64("min-confidence", 0.7, "lowest confidence of a match, from 0 to 1")
+	format := formatFlag(fs, "match instead of the CSV, e.g. '{{.Input.Name}}: {{.Business.ID}}'")
+	fs.Parse(args)
+
+	if *in == "" {
+		return usageError("-in is required")
+	}
+	tmpl, err := format()
+	if err != nil {
+		return err
+	}
+	inputs, err := readInputs(*in)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close(context.Background())
+
+	results := client.BulkMatch(yelp.WithProgress(ctx, printProgress("locations")), inputs, *minConfidence)
+	fmt.Fprintln(os.Stderr)
+	matched := 0
+	for _, r := range results {
+		if r.Matched() {
+			matched++
+		} else if r.Err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", r.Input.Name, r.Err)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "%d of %d matched\n", matched, len(results))
+
+	w := io.Writer(os.Stdout)
+	if *out != "-" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	if tmpl != nil {
+		for _, r := range results {
+			if err := writeFormatted(w, tmpl, r); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return yelp.WriteMatchCSV(w, results)
+}
+
+// readInputs reads the match inputs of the CSV file at path, or stdin for -.
+func readInputs(path string) ([]yelp.MatchInput, error) {
+	if path == "-" {
+		return yelp.ReadMatchCSV(os.Stdin)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return yelp.ReadMatchCSV(f)
+}
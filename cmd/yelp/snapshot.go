@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ivancevich/go-yelp/export"
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+// runSnapshot crawls an area, prints the businesses added, removed and
+// changed since the snapshot stored at -out and replaces it. With -parquet,
+// the businesses are written to a Parquet file too, for analytics tools. The
+// CLI links no SQL driver; programs storing snapshots in SQLite or Postgres
+// sync them with store/sqlstore.
+func runSnapshot(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("snapshot", flag.ExitOnError)
+	newClient := clientFlags(fs)
+	area := fs.String("area", "", `bounding box "swlat,swlng,nelat,nelng" tiled with searches, or a location searched as is`)
+	categories := fs.String("categories", "", "comma separated category aliases")
+	term := fs.String("term", "", "search term")
+	radius := fs.Int64("tile-radius", 2000, "radius in meters of the tiles of a bounding box")
+	out := fs.String("out", "snapshot.json", "path of the snapshot file")
+	parquet := fs.String("parquet", "", "path of a Parquet file to write the businesses to")
+	format := formatFlag(fs, "change, with .Change added, removed or changed")
+	fs.Parse(args)
+
+	if *area == "" {
+		return usageError("-area is required")
+	}
+	if *radius <= 0 {
+		return usageError("-tile-radius must be positive")
+	}
+	tmpl, err := format()
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close(context.Background())
+
+	so := yelp.SearchOptions{}
+	if *term != "" {
+		so.Term = term
+	}
+	if *categories != "" {
+		so.Categories = categories
+	}
+
+	var businesses []yelp.Business
+	if bounds, ok := parseBounds(*area); ok {
+		so.Radius = radius
+		businesses, err = client.TileSearch(yelp.WithProgress(ctx, printProgress("tiles")), bounds, so)
+		fmt.Fprintln(os.Stderr)
+	} else {
+		so.Location = area
+		businesses, err = yelp.SearchPages(client, so).All(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	sort.Slice(businesses, func(i, j int) bool { return businesses[i].ID < businesses[j].ID })
+
+	snap := yelp.Snapshot{
+		Area:       *area,
+		TakenAt:    time.Now().UTC(),
+		Businesses: businesses,
+	}
+	if *categories != "" {
+		snap.Categories = strings.Split(*categories, ",")
+	}
+
+	prev, err := readSnapshot(*out)
+	if err != nil {
+		return err
+	}
+	if prev != nil {
+		if err := printChanges(*prev, snap, tmpl); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(os.Stderr, "%d businesses\n", len(businesses))
+	if *parquet != "" {
+		if err := writeParquet(*parquet, businesses); err != nil {
+			return err
+		}
+	}
+	return writeSnapshot(*out, snap)
+}
+
+// printProgress returns a ProgressFunc drawing a progress line of steps,
+// named unit, on stderr.
+func printProgress(unit string) yelp.ProgressFunc {
+	return func(p yelp.Progress) {
+		fmt.Fprintf(os.Stderr, "\r%d/%d %s, %d errors, %.1f/s, ETA %s   ",
+			p.Done, p.Total, unit, p.Errors, p.Rate, p.ETA.Round(time.Second))
+	}
+}
+
+// parseBounds parses "swlat,swlng,nelat,nelng".
+func parseBounds(s string) (yelp.Bounds, bool) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 4 {
+		return yelp.Bounds{}, false
+	}
+	vals := make([]float64, 4)
+	for i, p := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(p), 64)
+		if err != nil {
+			return yelp.Bounds{}, false
+		}
+		vals[i] = v
+	}
+	b := yelp.Bounds{
+		SouthWest: yelp.Coordinates{Latitude: vals[0], Longitude: vals[1]},
+		NorthEast: yelp.Coordinates{Latitude: vals[2], Longitude: vals[3]},
+	}
+	return b, b.IsValid()
+}
+
+// change is a business added, removed or changed, as given to -format.
+type change struct {
+	Change string
+	yelp.Business
+}
+
+// printChanges prints the businesses added (+), removed (-) and changed (~)
+// from prev to cur, or formats them with tmpl when not nil.
+func printChanges(prev, cur yelp.Snapshot, tmpl *template.Template) error {
+	d := yelp.DiffSnapshots(prev, cur)
+	if tmpl == nil {
+		return d.WriteText(os.Stdout)
+	}
+	for _, b := range d.Added {
+		if err := writeFormatted(os.Stdout, tmpl, change{"added", b}); err != nil {
+			return err
+		}
+	}
+	for _, b := range d.Removed {
+		if err := writeFormatted(os.Stdout, tmpl, change{"removed", b}); err != nil {
+			return err
+		}
+	}
+	for _, c := range d.Changed {
+		if err := writeFormatted(os.Stdout, tmpl, change{"changed", c.New}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readSnapshot reads the snapshot at path, nil when there is none yet.
+func readSnapshot(path string) (*yelp.Snapshot, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	snap, err := yelp.ReadSnapshot(f)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return &snap, nil
+}
+
+// writeSnapshot replaces the snapshot at path with snap.
+func writeSnapshot(path string, snap yelp.Snapshot) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := snap.WriteTo(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// writeParquet replaces the Parquet file at path with the businesses.
+func writeParquet(path string, businesses []yelp.Business) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if err := export.WriteBusinessesParquet(f, businesses); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
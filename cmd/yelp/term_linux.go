@@ -0,0 +1,44 @@
+//go:build linux
+
+package main
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// makeRaw switches the terminal f to read keys one by one without echoing
+// them and returns the function restoring it.
+func makeRaw(f *os.File) (restore func(), err error) {
+	var old syscall.Termios
+	if err := ioctl(f, syscall.TCGETS, unsafe.Pointer(&old)); err != nil {
+		return nil, err
+	}
+	raw := old
+	raw.Lflag &^= syscall.ICANON | syscall.ECHO
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	if err := ioctl(f, syscall.TCSETS, unsafe.Pointer(&raw)); err != nil {
+		return nil, err
+	}
+	return func() { ioctl(f, syscall.TCSETS, unsafe.Pointer(&old)) }, nil
+}
+
+// termSize returns the number of rows and columns of the terminal f, 24 by
+// 80 when unknown.
+func termSize(f *os.File) (rows, cols int) {
+	var ws struct{ rows, cols, x, y uint16 }
+	if err := ioctl(f, syscall.TIOCGWINSZ, unsafe.Pointer(&ws)); err != nil || ws.rows == 0 {
+		return 24, 80
+	}
+	return int(ws.rows), int(ws.cols)
+}
+
+// ioctl calls the ioctl req on f with arg.
+func ioctl(f *os.File, req uintptr, arg unsafe.Pointer) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), req, uintptr(arg)); errno != 0 {
+		return errno
+	}
+	return nil
+}
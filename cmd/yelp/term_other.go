@@ -0,0 +1,18 @@
+//go:build !linux
+
+package main
+
+import (
+	"errors"
+	"os"
+)
+
+// makeRaw fails as raw terminals are only supported on Linux.
+func makeRaw(f *os.File) (restore func(), err error) {
+	return nil, errors.New("raw terminal not supported")
+}
+
+// termSize returns 24 rows by 80 columns.
+func termSize(f *os.File) (rows, cols int) {
+	return 24, 80
+}
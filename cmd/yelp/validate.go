@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+// validateChunk is the number of businesses checked between two writes of
+// the report, the work lost when validate-ids is interrupted.
+const validateChunk = 100
+
+// reportHeader is the header of the report of validate-ids.
+var reportHeader = []string{"id", "status", "current_id", "name", "error"}
+
+// runValidateIDs checks which of the business ids listed in a file are still
+// open, closed, deleted or merged into another business, and appends the
+// results to a CSV report. Ids already in the report are skipped, so an
+// interrupted run resumes where it stopped.
+func runValidateIDs(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("validate-ids", flag.ExitOnError)
+	newClient := clientFlags(fs)
+	report := fs.String("report", "validate-ids.csv", "CSV report the results are appended to")
+	workers := fs.Int("workers", 4, "businesses checked at the same time")
+	format := formatFlag(fs, "result, e.g. '{{.ID}} {{.Status}}'")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: yelp validate-ids [flags] ids.txt")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return usageError("usage: yelp validate-ids [flags] ids.txt")
+	}
+	tmpl, err := format()
+	if err != nil {
+		return err
+	}
+	ids, err := readIDs(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	done, err := readReport(*report)
+	if err != nil {
+		return err
+	}
+	todo := ids[:0]
+	for _, id := range ids {
+		if !done[id] {
+			todo = append(todo, id)
+		}
+	}
+	if len(todo) < len(ids) {
+		fmt.Fprintf(os.Stderr, "%d of %d already in %s\n", len(ids)-len(todo), len(ids), *report)
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	defer client.Close(context.Background())
+
+	f, err := os.OpenFile(*report, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w := csv.NewWriter(f)
+	if fi, err := f.Stat(); err != nil {
+		return err
+	} else if fi.Size() == 0 {
+		w.Write(reportHeader)
+	}
+
+	cc := yelp.NewClosureChecker(client)
+	cc.SetWorkers(*workers)
+	counts := map[yelp.ClosureStatus]int{}
+	var firstErr error
+	ctx = yelp.WithProgress(ctx, printProgress("businesses"))
+	for start := 0; start < len(todo) && ctx.Err() == nil; start += validateChunk {
+		end := start + validateChunk
+		if end > len(todo) {
+			end = len(todo)
+		}
+		for _, r := range cc.Check(ctx, todo[start:end]) {
+			if errors.Is(r.Err, context.Canceled) {
+				continue
+			}
+			counts[r.Status]++
+			errText := ""
+			if r.Err != nil {
+				errText = r.Err.Error()
+				if firstErr == nil {
+					firstErr = r.Err
+				}
+			}
+			w.Write([]string{r.ID, string(r.Status), r.CurrentID, r.Business.Name, errText})
+			if tmpl != nil {
+				if err := writeFormatted(os.Stdout, tmpl, r); err != nil {
+					return err
+				}
+			}
+		}
+		if w.Flush(); w.Error() != nil {
+			return w.Error()
+		}
+	}
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintf(os.Stderr, "%d open, %d closed, %d deleted, %d merged, %d unknown\n",
+		counts[yelp.ClosureOpen], counts[yelp.ClosureClosed], counts[yelp.ClosureDeleted],
+		counts[yelp.ClosureMerged], counts[yelp.ClosureUnknown])
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if firstErr != nil {
+		return fmt.Errorf("%d businesses not checked: %w", counts[yelp.ClosureUnknown], firstErr)
+	}
+	return nil
+}
+
+// readReport returns the ids of the report at path checked with a known
+// status. Ids whose check failed are checked again.
+func readReport(path string) (map[string]bool, error) {
+	done := map[string]bool{}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return done, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = len(reportHeader)
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			return done, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+		if rec[0] != reportHeader[0] && rec[1] != string(yelp.ClosureUnknown) {
+			done[rec[0]] = true
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+// runWarm fetches the details of the businesses listed in a file so they are
+// in the configured cache, e.g. overnight, before interactive traffic needs
+// them. Businesses already cached and fresh are not fetched again. Entries
+// older than the cache ttl, plus -keep-stale, are then removed from the cache.
+func runWarm(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("warm", flag.ExitOnError)
+	config := configFlags(fs)
+	ids := fs.String("ids", "", "file with a business id per line, - for stdin")
+	rate := fs.Float64("rate", 1, "requests per second")
+	burst := fs.Int("burst", 1, "requests sent at once after a quiet period")
+	keepStale := fs.Duration("keep-stale", 0, "how long past the cache ttl entries are kept, for stale-while-revalidate")
+	format := formatFlag(fs, "business warmed, e.g. '{{.Name}} ({{.Rating}}★)'")
+	fs.Parse(args)
+
+	if *ids == "" {
+		return usageError("-ids is required")
+	}
+	if *rate <= 0 {
+		return usageError("-rate must be positive")
+	}
+	tmpl, err := format()
+	if err != nil {
+		return err
+	}
+	list, err := readIDs(*ids)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config()
+	if err != nil {
+		return err
+	}
+	if cfg.CacheDir == "" {
+		return usageError("no cache configured, set cache.dir or " + yelp.EnvCacheDir)
+	}
+	cache, err := yelp.NewDirCache(cfg.CacheDir)
+	if err != nil {
+		return err
+	}
+	client, err := yelp.NewFromConfig(cfg,
+		yelp.WithPoliteness(time.Duration(float64(time.Second) / *rate), *burst))
+	if err != nil {
+		return err
+	}
+	defer client.Close(context.Background())
+
+	ctx = yelp.WithPriority(ctx, yelp.PriorityBatch)
+	warmed, err := client.BusinessesByIDs(yelp.WithProgress(ctx, printProgress("businesses")), list, yelp.BusinessOptions{})
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintf(os.Stderr, "%d of %d warmed\n", len(warmed), len(list))
+
+	ttl := cfg.CacheTTL
+	if ttl <= 0 {
+		ttl = yelp.DefaultCacheTTL
+	}
+	pruned, perr := cache.Prune(time.Now().Add(-ttl - *keepStale))
+	fmt.Fprintf(os.Stderr, "%d expired entries pruned\n", pruned)
+	if err == nil {
+		err = perr
+	}
+	if tmpl != nil {
+		for _, b := range warmed {
+			if err := writeFormatted(os.Stdout, tmpl, b); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// readIDs reads the business ids of the file at path, or stdin when path is
+// "-". Blank lines and lines starting with # are skipped.
+func readIDs(path string) ([]string, error) {
+	r := io.Reader(os.Stdin)
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	ids := []string{}
+	seen := map[string]bool{}
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		id := strings.TrimSpace(sc.Text())
+		if id == "" || strings.HasPrefix(id, "#") || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids, sc.Err()
+}
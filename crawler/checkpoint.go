@@ -0,0 +1,60 @@
+package crawler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+// state is the progress of a crawl, saved in the checkpoint file.
+type state struct {
+	Areas    []yelp.Bounds   `json:"areas,omitempty"`
+	Frontier []Task          `json:"frontier"`
+	Failed   []Task          `json:"failed,omitempty"`
+	Seen     map[string]bool `json:"seen"`
+}
+
+// newState returns the state of a new crawl.
+func newState() state {
+	return state{Frontier: []Task{}, Seen: map[string]bool{}}
+}
+
+// loadState reads the state saved at path, nil when there is none.
+func loadState(path string) (*state, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s := newState()
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("crawler: reading checkpoint %s: %w", path, err)
+	}
+	if s.Seen == nil {
+		s.Seen = map[string]bool{}
+	}
+	return &s, nil
+}
+
+// save writes the state to the checkpoint file, replacing it atomically so a
+// crash while saving keeps the previous checkpoint.
+func (cr *Crawler) save() error {
+	if cr.opts.Checkpoint == "" {
+		return nil
+	}
+	data, err := json.Marshal(cr.state)
+	if err != nil {
+		return err
+	}
+	tmp := cr.opts.Checkpoint + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, cr.opts.Checkpoint)
+}
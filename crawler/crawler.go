@@ -0,0 +1,334 @@
+// Package crawler pulls large amounts of Yelp data: it searches areas tile
+// by tile and fetches business details, keeping its frontier of pending work
+// in a checkpoint file so a crawl stopped by a crash, a deploy or the daily
+// quota resumes where it left off.
+//
+//	cr, err := crawler.New(client, crawler.Options{
+//		Checkpoint: "crawl.json",
+//		Details:    true,
+//		Delay:      200 * time.Millisecond,
+//	})
+//	if !cr.Resumed() {
+//		cr.SeedArea(bounds)
+//	}
+//	err = cr.Run(ctx, func(b yelp.Business) error {
+//		return store(b)
+//	})
+//
+// Businesses are handled at least once: the work done since the last
+// checkpoint is done again after a crash, so handle should be idempotent.
+package crawler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+const (
+	// defaultTileRadius is the radius in meters of the tiles seeded by
+	// SeedArea when Options.TileRadius is zero.
+	defaultTileRadius = 2000
+
+	// defaultMaxAttempts is the number of attempts of a task when
+	// Options.MaxAttempts is zero.
+	defaultMaxAttempts = 3
+
+	// defaultRateLimitWait is the wait after a 429 response when
+	// Options.RateLimitWait is zero.
+	defaultRateLimitWait = time.Minute
+
+	// checkpointEvery is the number of tasks done between checkpoints.
+	checkpointEvery = 10
+
+	// searchWindow is the number of results a search can be paginated to.
+	// Tiles with more are split.
+	searchWindow = 1000
+)
+
+// Options configures a Crawler.
+type Options struct {
+	// Checkpoint is the path of the file the progress is saved to and
+	// resumed from. Progress is not saved when empty.
+	Checkpoint string
+
+	// Search holds the filters of the tile searches, such as Categories.
+	Search yelp.SearchOptions
+
+	// TileRadius is the radius in meters of the tiles covering a seeded
+	// area, 2000 when zero.
+	TileRadius float64
+
+	// Details makes the crawler fetch the details of the businesses found in
+	// areas instead of handling the search results.
+	Details bool
+
+	// Delay is the least time between two tasks, to stay well below the
+	// rate limits.
+	Delay time.Duration
+
+	// MaxAttempts is the number of times a failing task is tried, 3 when
+	// zero.
+	MaxAttempts int
+
+	// RateLimitWait is the wait after Yelp answers 429, a minute when zero.
+	RateLimitWait time.Duration
+
+	// Progress, if not nil, receives the progress of Run after each task.
+	// The total grows as tiles are split and businesses queued.
+	Progress yelp.ProgressFunc
+}
+
+// TaskKind is the kind of a Task.
+type TaskKind string
+
+// Available task kinds.
+const (
+	TaskTile     TaskKind = "tile"
+	TaskBusiness TaskKind = "business"
+)
+
+// Task is a unit of work of the frontier.
+type Task struct {
+	Kind     TaskKind   `json:"kind"`
+	Tile     *yelp.Tile `json:"tile,omitempty"`
+	ID       string     `json:"id,omitempty"`
+	Attempts int        `json:"attempts,omitempty"`
+	Err      string     `json:"error,omitempty"`
+}
+
+// Crawler crawls areas and businesses.
+type Crawler struct {
+	client  yelp.Client
+	opts    Options
+	state   state
+	resumed bool
+	last    time.Time
+}
+
+// New returns a Crawler making requests with c. The progress saved in
+// opts.Checkpoint, if any, is loaded.
+func New(c yelp.Client, opts Options) (*Crawler, error) {
+	if opts.TileRadius <= 0 {
+		opts.TileRadius = defaultTileRadius
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = defaultMaxAttempts
+	}
+	if opts.RateLimitWait <= 0 {
+		opts.RateLimitWait = defaultRateLimitWait
+	}
+
+	cr := &Crawler{client: c, opts: opts, state: newState()}
+	if opts.Checkpoint != "" {
+		loaded, err := loadState(opts.Checkpoint)
+		if err != nil {
+			return nil, err
+		}
+		if loaded != nil {
+			cr.state, cr.resumed = *loaded, true
+		}
+	}
+	return cr, nil
+}
+
+// Resumed returns true when progress was loaded from the checkpoint.
+func (cr *Crawler) Resumed() bool {
+	return cr.resumed
+}
+
+// SeedArea adds the tiles covering bounds to the frontier. An area already
+// seeded is ignored.
+func (cr *Crawler) SeedArea(bounds yelp.Bounds) {
+	for _, a := range cr.state.Areas {
+		if a == bounds {
+			return
+		}
+	}
+	cr.state.Areas = append(cr.state.Areas, bounds)
+	for _, t := range yelp.CoverBounds(bounds, cr.opts.TileRadius) {
+		t := t
+		cr.state.Frontier = append(cr.state.Frontier, Task{Kind: TaskTile, Tile: &t})
+	}
+}
+
+// SeedIDs adds the businesses with the given ids to the frontier. Businesses
+// already seen are ignored.
+func (cr *Crawler) SeedIDs(ids ...string) {
+	for _, id := range ids {
+		if !cr.state.Seen[id] {
+			cr.state.Seen[id] = true
+			cr.state.Frontier = append(cr.state.Frontier, Task{Kind: TaskBusiness, ID: id})
+		}
+	}
+}
+
+// Pending returns the number of tasks left in the frontier.
+func (cr *Crawler) Pending() int {
+	return len(cr.state.Frontier)
+}
+
+// Failed returns the tasks given up on after Options.MaxAttempts.
+func (cr *Crawler) Failed() []Task {
+	return cr.state.Failed
+}
+
+// Run works through the frontier, calling handle with every business found,
+// until it is empty. It stops, saving its progress, when ctx is done, when
+// the quota is used up, with an error of class yelp.ErrorClassQuota, or when
+// handle fails.
+func (cr *Crawler) Run(ctx context.Context, handle func(yelp.Business) error) error {
+	done := 0
+	pt := yelp.NewProgressTracker(cr.opts.Progress)
+	pt.Add(len(cr.state.Frontier))
+	for len(cr.state.Frontier) > 0 {
+		if err := cr.throttle(ctx); err != nil {
+			return cr.stop(err)
+		}
+
+		pending := len(cr.state.Frontier)
+		task := cr.state.Frontier[0]
+		cr.state.Frontier = cr.state.Frontier[1:]
+		err := cr.run(ctx, task, handle)
+
+		class := yelp.ClassifyError(err)
+		switch {
+		case err == nil:
+		case class == yelp.ErrorClassRateLimited:
+			cr.state.Frontier = append([]Task{task}, cr.state.Frontier...)
+			if err := sleep(ctx, cr.opts.RateLimitWait); err != nil {
+				return cr.stop(err)
+			}
+			continue
+		case class == yelp.ErrorClassQuota, ctx.Err() != nil, errors.As(err, new(handlerError)):
+			cr.state.Frontier = append([]Task{task}, cr.state.Frontier...)
+			return cr.stop(err)
+		default:
+			task.Attempts++
+			task.Err = err.Error()
+			permanent := class == yelp.ErrorClassNotFound || class == yelp.ErrorClassInvalidRequest
+			if permanent || task.Attempts >= cr.opts.MaxAttempts {
+				cr.state.Failed = append(cr.state.Failed, task)
+			} else {
+				cr.state.Frontier = append(cr.state.Frontier, task)
+			}
+		}
+
+		pt.Add(len(cr.state.Frontier) - (pending - 1))
+		pt.Step(err)
+
+		if done++; done%checkpointEvery == 0 {
+			if err := cr.save(); err != nil {
+				return err
+			}
+		}
+	}
+	return cr.save()
+}
+
+// handlerError is an error returned by the handle function of Run.
+type handlerError struct {
+	err error
+}
+
+func (e handlerError) Error() string { return e.err.Error() }
+func (e handlerError) Unwrap() error { return e.err }
+
+// run does task.
+func (cr *Crawler) run(ctx context.Context, task Task, handle func(yelp.Business) error) error {
+	emit := func(b yelp.Business) error {
+		if err := handle(b); err != nil {
+			return handlerError{err}
+		}
+		return nil
+	}
+
+	if task.Kind == TaskBusiness {
+		b, err := cr.client.BusinessByID(ctx, task.ID, yelp.BusinessOptions{})
+		if err != nil {
+			return err
+		}
+		return emit(b)
+	}
+
+	p := yelp.SearchPages(cr.client, task.Tile.SearchOptions(cr.opts.Search))
+	businesses, err := p.Next(ctx)
+	if err != nil {
+		return err
+	}
+	if p.Total() > searchWindow && task.Tile.CanSplit() {
+		for _, t := range task.Tile.Split() {
+			t := t
+			cr.state.Frontier = append(cr.state.Frontier, Task{Kind: TaskTile, Tile: &t})
+		}
+		return nil
+	}
+
+	for {
+		for _, b := range businesses {
+			if cr.state.Seen[b.ID] || !cr.inAreas(b.Coodinates) {
+				continue
+			}
+			cr.state.Seen[b.ID] = true
+			if cr.opts.Details {
+				cr.state.Frontier = append(cr.state.Frontier, Task{Kind: TaskBusiness, ID: b.ID})
+			} else if err := emit(b); err != nil {
+				delete(cr.state.Seen, b.ID)
+				return err
+			}
+		}
+		if !p.HasNext() {
+			return nil
+		}
+		if err := cr.throttle(ctx); err != nil {
+			return err
+		}
+		if businesses, err = p.Next(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// inAreas returns true when c is in a seeded area.
+func (cr *Crawler) inAreas(c yelp.Coordinates) bool {
+	for _, a := range cr.state.Areas {
+		if a.Contains(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// throttle waits until Options.Delay passed since the previous request.
+func (cr *Crawler) throttle(ctx context.Context) error {
+	if err := sleep(ctx, time.Until(cr.last.Add(cr.opts.Delay))); err != nil {
+		return err
+	}
+	cr.last = time.Now()
+	return nil
+}
+
+// stop saves the progress and returns err.
+func (cr *Crawler) stop(err error) error {
+	if saveErr := cr.save(); saveErr != nil {
+		return errors.Join(err, saveErr)
+	}
+	return err
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,119 @@
+// Package export writes businesses and reviews as records with a flat,
+// stable schema, so Yelp pulls load directly into analytics tools: as newline
+// delimited JSON, read by DuckDB with read_json and Spark with
+// spark.read.json, or as Parquet files, see WriteBusinessesParquet.
+//
+// Fields are only ever added to the records, never renamed or removed.
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+// BusinessRecord is the exported form of a business.
+type BusinessRecord struct {
+	ID          string   `json:"id"`
+	Alias       string   `json:"alias"`
+	Name        string   `json:"name"`
+	URL         string   `json:"url"`
+	ImageURL    string   `json:"image_url"`
+	Phone       string   `json:"phone"`
+	IsClaimed   bool     `json:"is_claimed"`
+	IsClosed    bool     `json:"is_closed"`
+	Price       string   `json:"price"`
+	Rating      float64  `json:"rating"`
+	ReviewCount int64    `json:"review_count"`
+	Categories  []string `json:"categories"`
+	Latitude    float64  `json:"latitude"`
+	Longitude   float64  `json:"longitude"`
+	Address     string   `json:"address"`
+	City        string   `json:"city"`
+	State       string   `json:"state"`
+	ZipCode     string   `json:"zip_code"`
+	Country     string   `json:"country"`
+	Locale      string   `json:"locale"`
+}
+
+// NewBusinessRecord returns the record of b.
+func NewBusinessRecord(b yelp.Business) BusinessRecord {
+	categories := make([]string, len(b.Categories))
+	for i, c := range b.Categories {
+		categories[i] = c.Alias
+	}
+	return BusinessRecord{
+		ID:          b.ID,
+		Alias:       b.Alias,
+		Name:        b.Name,
+		URL:         b.URL,
+		ImageURL:    b.ImageURL,
+		Phone:       b.Phone,
+		IsClaimed:   b.IsClaimed,
+		IsClosed:    b.IsClosed,
+		Price:       b.Price,
+		Rating:      b.Rating,
+		ReviewCount: b.ReviewCount,
+		Categories:  categories,
+		Latitude:    b.Coodinates.Latitude,
+		Longitude:   b.Coodinates.Longitude,
+		Address:     strings.Join(b.Location.DisplayAddress, ", "),
+		City:        b.Location.City,
+		State:       b.Location.State,
+		ZipCode:     b.Location.ZipCode,
+		Country:     b.Location.Country,
+		Locale:      b.Locale,
+	}
+}
+
+// ReviewRecord is the exported form of a review.
+type ReviewRecord struct {
+	BusinessID  string  `json:"business_id"`
+	ID          string  `json:"id"`
+	Rating      float64 `json:"rating"`
+	Text        string  `json:"text"`
+	TimeCreated string  `json:"time_created"`
+	URL         string  `json:"url"`
+	UserID      string  `json:"user_id"`
+	UserName    string  `json:"user_name"`
+}
+
+// NewReviewRecord returns the record of r, a review of the business with id
+// businessID.
+func NewReviewRecord(businessID string, r yelp.Review) ReviewRecord {
+	return ReviewRecord{
+		BusinessID:  businessID,
+		ID:          r.ID,
+		Rating:      r.Rating,
+		Text:        r.Text,
+		TimeCreated: r.TimeCreated,
+		URL:         r.URL,
+		UserID:      r.User.ID,
+		UserName:    r.User.Name,
+	}
+}
+
+// WriteBusinesses writes a record per business to w.
+func WriteBusinesses(w io.Writer, businesses []yelp.Business) error {
+	enc := json.NewEncoder(w)
+	for _, b := range businesses {
+		if err := enc.Encode(NewBusinessRecord(b)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteReviews writes a record per review of the business with id businessID
+// to w.
+func WriteReviews(w io.Writer, businessID string, reviews []yelp.Review) error {
+	enc := json.NewEncoder(w)
+	for _, r := range reviews {
+		if err := enc.Encode(NewReviewRecord(businessID, r)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
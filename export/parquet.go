@@ -0,0 +1,342 @@
+package export
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+// parquetMagic starts and ends Parquet files.
+const parquetMagic = "PAR1"
+
+// Parquet physical types, encodings and other enums of the format.
+const (
+	parquetBoolean   = 0
+	parquetInt64     = 2
+	parquetDouble    = 5
+	parquetByteArray = 6
+
+	parquetRequired = 0
+	parquetUTF8     = 0
+
+	parquetPlain        = 0
+	parquetRLE          = 3
+	parquetUncompressed = 0
+	parquetDataPage     = 0
+)
+
+// WriteBusinessesParquet writes a BusinessRecord per business to w as a
+// Parquet file with a column per field, named as in the JSON records.
+// Categories are joined with commas. The file is written uncompressed with a
+// single row group, so Spark, DuckDB and pandas read it as is.
+func WriteBusinessesParquet(w io.Writer, businesses []yelp.Business) error {
+	records := make([]BusinessRecord, len(businesses))
+	for i, b := range businesses {
+		records[i] = NewBusinessRecord(b)
+	}
+	return writeParquet(w, records)
+}
+
+// WriteReviewsParquet writes the records, made with NewReviewRecord, to w as
+// a Parquet file, see WriteBusinessesParquet.
+func WriteReviewsParquet(w io.Writer, records []ReviewRecord) error {
+	return writeParquet(w, records)
+}
+
+// parquetColumn is a column of a Parquet file being written.
+type parquetColumn struct {
+	name  string
+	typ   int32
+	field int
+	data  []byte
+	bits  int
+}
+
+// writeParquet writes records, a slice of structs with string, bool, int64,
+// float64 and []string fields, to w as a Parquet file.
+func writeParquet(w io.Writer, records interface{}) error {
+	rows := reflect.ValueOf(records)
+	t := rows.Type().Elem()
+	columns := []*parquetColumn{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		col := &parquetColumn{name: strings.Split(f.Tag.Get("json"), ",")[0], field: i}
+		switch f.Type.Kind() {
+		case reflect.String, reflect.Slice:
+			col.typ = parquetByteArray
+		case reflect.Bool:
+			col.typ = parquetBoolean
+		case reflect.Int64:
+			col.typ = parquetInt64
+		case reflect.Float64:
+			col.typ = parquetDouble
+		default:
+			return fmt.Errorf("export: unsupported field %s", f.Name)
+		}
+		columns = append(columns, col)
+	}
+
+	for r := 0; r < rows.Len(); r++ {
+		row := rows.Index(r)
+		for _, col := range columns {
+			col.append(row.Field(col.field))
+		}
+	}
+
+	cw := &countingWriter{w: w}
+	if _, err := io.WriteString(cw, parquetMagic); err != nil {
+		return err
+	}
+	chunks := []parquetChunk{}
+	var total int64
+	for _, col := range columns {
+		header := thriftWriter{}
+		header.i32(1, parquetDataPage)
+		header.i32(2, int32(len(col.data)))
+		header.i32(3, int32(len(col.data)))
+		header.beginStruct(5)
+		header.i32(1, int32(rows.Len()))
+		header.i32(2, parquetPlain)
+		// The level encodings are required fields; the columns are required
+		// and not repeated, so the page holds no levels to encode.
+		header.i32(3, parquetRLE)
+		header.i32(4, parquetRLE)
+		header.endStruct()
+		header.stop()
+
+		offset := cw.n
+		if _, err := cw.Write(header.buf); err != nil {
+			return err
+		}
+		if _, err := cw.Write(col.data); err != nil {
+			return err
+		}
+		size := cw.n - offset
+		total += size
+		chunks = append(chunks, parquetChunk{col: col, offset: offset, size: size})
+	}
+
+	meta := fileMetaData(columns, chunks, int64(rows.Len()), total)
+	meta = binary.LittleEndian.AppendUint32(meta, uint32(len(meta)))
+	meta = append(meta, parquetMagic...)
+	_, err := cw.Write(meta)
+	return err
+}
+
+// append adds v to the PLAIN encoded values of the column.
+func (col *parquetColumn) append(v reflect.Value) {
+	switch col.typ {
+	case parquetByteArray:
+		s := ""
+		if v.Kind() == reflect.Slice {
+			s = strings.Join(v.Interface().([]string), ",")
+		} else {
+			s = v.String()
+		}
+		col.data = binary.LittleEndian.AppendUint32(col.data, uint32(len(s)))
+		col.data = append(col.data, s...)
+	case parquetBoolean:
+		if col.bits%8 == 0 {
+			col.data = append(col.data, 0)
+		}
+		if v.Bool() {
+			col.data[len(col.data)-1] |= 1 << (col.bits % 8)
+		}
+		col.bits++
+	case parquetInt64:
+		col.data = binary.LittleEndian.AppendUint64(col.data, uint64(v.Int()))
+	case parquetDouble:
+		col.data = binary.LittleEndian.AppendUint64(col.data, math.Float64bits(v.Float()))
+	}
+}
+
+// parquetChunk is where the page of a column was written.
+type parquetChunk struct {
+	col    *parquetColumn
+	offset int64
+	size   int64
+}
+
+// fileMetaData returns the footer of a Parquet file with a single row group.
+func fileMetaData(columns []*parquetColumn, chunks []parquetChunk, numRows, total int64) []byte {
+	tw := thriftWriter{}
+	tw.i32(1, 1)
+	tw.beginList(2, len(columns)+1)
+	tw.beginElem()
+	tw.binary(4, "schema")
+	tw.i32(5, int32(len(columns)))
+	tw.endElem()
+	for _, col := range columns {
+		tw.beginElem()
+		tw.i32(1, col.typ)
+		tw.i32(3, parquetRequired)
+		tw.binary(4, col.name)
+		if col.typ == parquetByteArray {
+			tw.i32(6, parquetUTF8)
+		}
+		tw.endElem()
+	}
+	tw.i64(3, numRows)
+
+	tw.beginList(4, 1)
+	tw.beginElem()
+	tw.beginList(1, len(chunks))
+	for _, ch := range chunks {
+		tw.beginElem()
+		tw.i64(2, ch.offset)
+		tw.beginStruct(3)
+		tw.i32(1, ch.col.typ)
+		tw.i32List(2, parquetPlain, parquetRLE)
+		tw.binaryList(3, ch.col.name)
+		tw.i32(4, parquetUncompressed)
+		tw.i64(5, numRows)
+		tw.i64(6, ch.size)
+		tw.i64(7, ch.size)
+		tw.i64(9, ch.offset)
+		tw.endStruct()
+		tw.endElem()
+	}
+	tw.i64(2, total)
+	tw.i64(3, numRows)
+	tw.endElem()
+
+	tw.binary(6, "go-yelp export")
+	tw.stop()
+	return tw.buf
+}
+
+// Types of the Thrift compact protocol.
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter encodes structs with the Thrift compact protocol, as the
+// metadata of Parquet files.
+type thriftWriter struct {
+	buf  []byte
+	last int16
+	// stack holds the last field ids of the enclosing structs.
+	stack []int16
+}
+
+// field writes the header of field id of type typ.
+func (tw *thriftWriter) field(id int16, typ byte) {
+	if delta := id - tw.last; delta > 0 && delta <= 15 {
+		tw.buf = append(tw.buf, byte(delta)<<4|typ)
+	} else {
+		tw.buf = append(tw.buf, typ)
+		tw.varint(uint64(zigzag(int64(id))))
+	}
+	tw.last = id
+}
+
+func (tw *thriftWriter) varint(v uint64) {
+	tw.buf = binary.AppendUvarint(tw.buf, v)
+}
+
+func zigzag(v int64) uint64 {
+	return uint64(v<<1) ^ uint64(v>>63)
+}
+
+func (tw *thriftWriter) i32(id int16, v int32) {
+	tw.field(id, thriftI32)
+	tw.varint(zigzag(int64(v)))
+}
+
+func (tw *thriftWriter) i64(id int16, v int64) {
+	tw.field(id, thriftI64)
+	tw.varint(zigzag(v))
+}
+
+func (tw *thriftWriter) binary(id int16, s string) {
+	tw.field(id, thriftBinary)
+	tw.varint(uint64(len(s)))
+	tw.buf = append(tw.buf, s...)
+}
+
+// listHeader writes the header of a list of n elements of type typ.
+func (tw *thriftWriter) listHeader(n int, typ byte) {
+	if n < 15 {
+		tw.buf = append(tw.buf, byte(n)<<4|typ)
+		return
+	}
+	tw.buf = append(tw.buf, 0xf0|typ)
+	tw.varint(uint64(n))
+}
+
+// beginStruct starts the struct field id.
+func (tw *thriftWriter) beginStruct(id int16) {
+	tw.field(id, thriftStruct)
+	tw.stack = append(tw.stack, tw.last)
+	tw.last = 0
+}
+
+// endStruct ends the struct started by beginStruct.
+func (tw *thriftWriter) endStruct() {
+	tw.stop()
+	tw.last = tw.stack[len(tw.stack)-1]
+	tw.stack = tw.stack[:len(tw.stack)-1]
+}
+
+// beginList starts the list field id of n structs, each started with
+// beginElem and ended with endElem.
+func (tw *thriftWriter) beginList(id int16, n int) {
+	tw.field(id, thriftList)
+	tw.listHeader(n, thriftStruct)
+}
+
+// beginElem starts a struct element of a list.
+func (tw *thriftWriter) beginElem() {
+	tw.stack = append(tw.stack, tw.last)
+	tw.last = 0
+}
+
+// endElem ends a struct element of a list.
+func (tw *thriftWriter) endElem() {
+	tw.endStruct()
+}
+
+// i32List writes the list field id of the values.
+func (tw *thriftWriter) i32List(id int16, values ...int32) {
+	tw.field(id, thriftList)
+	tw.listHeader(len(values), thriftI32)
+	for _, v := range values {
+		tw.varint(zigzag(int64(v)))
+	}
+}
+
+// binaryList writes the list field id of the strings.
+func (tw *thriftWriter) binaryList(id int16, values ...string) {
+	tw.field(id, thriftList)
+	tw.listHeader(len(values), thriftBinary)
+	for _, s := range values {
+		tw.varint(uint64(len(s)))
+		tw.buf = append(tw.buf, s...)
+	}
+}
+
+// stop ends a struct.
+func (tw *thriftWriter) stop() {
+	tw.buf = append(tw.buf, 0)
+}
+
+// countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,301 @@
+package export_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/ivancevich/go-yelp/export"
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+// thriftReader decodes structs of the Thrift compact protocol into maps of
+// field id to value: int64, bool, []byte, []interface{} or another map.
+type thriftReader struct {
+	t   *testing.T
+	buf []byte
+	pos int
+}
+
+func (r *thriftReader) byte() byte {
+	if r.pos >= len(r.buf) {
+		r.t.Fatalf("thrift: read past the end at %d", r.pos)
+	}
+	b := r.buf[r.pos]
+	r.pos++
+	return b
+}
+
+func (r *thriftReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.buf[r.pos:])
+	if n <= 0 {
+		r.t.Fatalf("thrift: bad varint at %d", r.pos)
+	}
+	r.pos += n
+	return v
+}
+
+func (r *thriftReader) zigzag() int64 {
+	v := r.uvarint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *thriftReader) value(typ byte) interface{} {
+	switch typ {
+	case 1, 2:
+		return typ == 1
+	case 3, 4, 5, 6:
+		return r.zigzag()
+	case 8:
+		n := int(r.uvarint())
+		v := r.buf[r.pos : r.pos+n]
+		r.pos += n
+		return v
+	case 9:
+		h := r.byte()
+		n, elem := int(h>>4), h&0x0f
+		if n == 15 {
+			n = int(r.uvarint())
+		}
+		list := make([]interface{}, n)
+		for i := range list {
+			list[i] = r.value(elem)
+		}
+		return list
+	case 12:
+		return r.structure()
+	}
+	r.t.Fatalf("thrift: unexpected type %d at %d", typ, r.pos)
+	return nil
+}
+
+func (r *thriftReader) structure() map[int16]interface{} {
+	fields := map[int16]interface{}{}
+	var last int16
+	for {
+		h := r.byte()
+		if h == 0 {
+			return fields
+		}
+		id := int16(h >> 4)
+		if id == 0 {
+			id = int16(r.zigzag())
+		} else {
+			id += last
+		}
+		last = id
+		fields[id] = r.value(h & 0x0f)
+	}
+}
+
+// parquetFile is a Parquet file decoded by readParquet.
+type parquetFile struct {
+	meta    map[int16]interface{}
+	columns map[string][]interface{}
+	names   []string
+}
+
+// readParquet decodes the footer of data and the values of its columns,
+// checking the layout a Parquet reader relies on.
+func readParquet(t *testing.T, data []byte) parquetFile {
+	t.Helper()
+	if !bytes.HasPrefix(data, []byte("PAR1")) || !bytes.HasSuffix(data, []byte("PAR1")) {
+		t.Fatal("missing PAR1 magic")
+	}
+	metaLen := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	metaStart := len(data) - 8 - metaLen
+	r := &thriftReader{t: t, buf: data[:len(data)-8], pos: metaStart}
+	meta := r.structure()
+	if r.pos != len(data)-8 {
+		t.Fatalf("footer decoded to %d, want %d", r.pos, len(data)-8)
+	}
+
+	pf := parquetFile{meta: meta, columns: map[string][]interface{}{}}
+	numRows := meta[3].(int64)
+	rowGroups := meta[4].([]interface{})
+	if len(rowGroups) != 1 {
+		t.Fatalf("%d row groups, want 1", len(rowGroups))
+	}
+	rg := rowGroups[0].(map[int16]interface{})
+	if rg[3].(int64) != numRows {
+		t.Errorf("row group has %d rows, file %d", rg[3], numRows)
+	}
+
+	next, total := int64(4), int64(0)
+	for _, c := range rg[1].([]interface{}) {
+		cm := c.(map[int16]interface{})[3].(map[int16]interface{})
+		name := string(cm[3].([]interface{})[0].([]byte))
+		typ, offset, size := cm[1].(int64), cm[9].(int64), cm[6].(int64)
+		if offset != next {
+			t.Errorf("column %s starts at %d, want %d", name, offset, next)
+		}
+		if cm[7].(int64) != size || cm[4].(int64) != 0 || cm[5].(int64) != numRows {
+			t.Errorf("column %s: sizes %d/%d, codec %d, %d values", name, size, cm[7], cm[4], cm[5])
+		}
+		next += size
+		total += size
+
+		pr := &thriftReader{t: t, buf: data, pos: int(offset)}
+		page := pr.structure()
+		dph := page[5].(map[int16]interface{})
+		if page[1].(int64) != 0 || dph[1].(int64) != numRows || dph[2].(int64) != 0 || dph[3].(int64) != 3 || dph[4].(int64) != 3 {
+			t.Errorf("column %s: unexpected page header %v", name, page)
+		}
+		pageLen := int(page[2].(int64))
+		if int64(pr.pos+pageLen) != offset+size {
+			t.Errorf("column %s: page ends at %d, chunk at %d", name, pr.pos+pageLen, offset+size)
+		}
+		pf.names = append(pf.names, name)
+		pf.columns[name] = decodePlain(t, typ, data[pr.pos:pr.pos+pageLen], int(numRows))
+	}
+	if next != int64(metaStart) {
+		t.Errorf("columns end at %d, footer starts at %d", next, metaStart)
+	}
+	if rg[2].(int64) != total {
+		t.Errorf("total_byte_size %d, want the sum of the chunks %d", rg[2], total)
+	}
+	return pf
+}
+
+// decodePlain decodes n PLAIN encoded values of the physical type typ.
+func decodePlain(t *testing.T, typ int64, data []byte, n int) []interface{} {
+	values := make([]interface{}, n)
+	pos := 0
+	for i := range values {
+		switch typ {
+		case 0:
+			values[i] = data[i/8]>>(i%8)&1 == 1
+		case 2:
+			values[i] = int64(binary.LittleEndian.Uint64(data[pos:]))
+			pos += 8
+		case 5:
+			values[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[pos:]))
+			pos += 8
+		case 6:
+			l := int(binary.LittleEndian.Uint32(data[pos:]))
+			values[i] = string(data[pos+4 : pos+4+l])
+			pos += 4 + l
+		default:
+			t.Fatalf("unexpected physical type %d", typ)
+		}
+	}
+	return values
+}
+
+func testBusinesses() []yelp.Business {
+	return []yelp.Business{
+		{
+			ID: "WavvLdfdP6g8aZTtbBQHTw", Name: "Gary Danko", Rating: 4.5, ReviewCount: 5296, IsClaimed: true,
+			Categories: []yelp.Category{{Alias: "newamerican"}, {Alias: "french"}},
+			Location:   yelp.Location{City: "San Francisco"},
+		},
+		{ID: "closed", Name: "Café ✓", Rating: 3, ReviewCount: 12, IsClosed: true},
+		{ID: "empty"},
+	}
+}
+
+func TestWriteBusinessesParquet(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := export.WriteBusinessesParquet(buf, testBusinesses()); err != nil {
+		t.Fatal(err)
+	}
+	pf := readParquet(t, buf.Bytes())
+
+	if v := pf.meta[1].(int64); v != 1 {
+		t.Errorf("version %d, want 1", v)
+	}
+	if n := pf.meta[3].(int64); n != 3 {
+		t.Errorf("%d rows, want 3", n)
+	}
+
+	// The schema has more than 15 elements, so its list has a long header.
+	schema := pf.meta[2].([]interface{})
+	if len(schema) < 16 {
+		t.Fatalf("%d schema elements, want a column per field", len(schema))
+	}
+	root := schema[0].(map[int16]interface{})
+	if int(root[5].(int64)) != len(schema)-1 {
+		t.Errorf("root has %d children, want %d", root[5], len(schema)-1)
+	}
+	for i, e := range schema[1:] {
+		el := e.(map[int16]interface{})
+		if name := string(el[4].([]byte)); name != pf.names[i] {
+			t.Errorf("schema column %d is %s, chunk %s", i, name, pf.names[i])
+		}
+		if el[3].(int64) != 0 {
+			t.Errorf("column %s is not required", pf.names[i])
+		}
+		if _, utf8 := el[6]; utf8 != (el[1].(int64) == 6) {
+			t.Errorf("column %s: converted type %v for type %d", pf.names[i], el[6], el[1])
+		}
+	}
+
+	want := map[string][]interface{}{
+		"id":           {"WavvLdfdP6g8aZTtbBQHTw", "closed", "empty"},
+		"name":         {"Gary Danko", "Café ✓", ""},
+		"is_claimed":   {true, false, false},
+		"is_closed":    {false, true, false},
+		"rating":       {4.5, 3.0, 0.0},
+		"review_count": {int64(5296), int64(12), int64(0)},
+		"categories":   {"newamerican,french", "", ""},
+		"city":         {"San Francisco", "", ""},
+	}
+	for name, values := range want {
+		got := pf.columns[name]
+		for i := range values {
+			if i >= len(got) || got[i] != values[i] {
+				t.Errorf("column %s = %v, want %v", name, got, values)
+				break
+			}
+		}
+	}
+}
+
+func TestWriteReviewsParquet(t *testing.T) {
+	records := []export.ReviewRecord{
+		{BusinessID: "b1", ID: "r1", Rating: 5, Text: "Great", UserName: "Ann"},
+		{BusinessID: "b1", ID: "r2", Rating: 2},
+	}
+	buf := &bytes.Buffer{}
+	if err := export.WriteReviewsParquet(buf, records); err != nil {
+		t.Fatal(err)
+	}
+	pf := readParquet(t, buf.Bytes())
+	if got := pf.columns["id"]; len(got) != 2 || got[0] != "r1" || got[1] != "r2" {
+		t.Errorf("column id = %v", got)
+	}
+	if got := pf.columns["rating"]; len(got) != 2 || got[0] != 5.0 || got[1] != 2.0 {
+		t.Errorf("column rating = %v", got)
+	}
+}
+
+// failingWriter fails once n bytes were written.
+type failingWriter struct {
+	n int
+}
+
+var errWrite = errors.New("write failed")
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		n := w.n
+		w.n = 0
+		return n, errWrite
+	}
+	w.n -= len(p)
+	return len(p), nil
+}
+
+func TestWriteParquetError(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := export.WriteBusinessesParquet(buf, testBusinesses()); err != nil {
+		t.Fatal(err)
+	}
+	for n := 0; n < buf.Len(); n += 7 {
+		if err := export.WriteBusinessesParquet(&failingWriter{n: n}, testBusinesses()); !errors.Is(err, errWrite) {
+			t.Fatalf("failing after %d bytes: got %v, want the write error", n, err)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package feed
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+// atomNamespace is the XML namespace of Atom.
+const atomNamespace = "http://www.w3.org/2005/Atom"
+
+// atomFeed is the root of an Atom document.
+type atomFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	Xmlns   string      `xml:"xmlns,attr"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Link    atomLink    `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+// atomEntry is a review.
+type atomEntry struct {
+	ID        string     `xml:"id"`
+	Title     string     `xml:"title"`
+	Updated   string     `xml:"updated"`
+	Published string     `xml:"published"`
+	Link      *atomLink  `xml:"link,omitempty"`
+	Author    atomAuthor `xml:"author"`
+	Summary   string     `xml:"summary"`
+}
+
+// atomLink is a link to a Yelp page.
+type atomLink struct {
+	Href string `xml:"href,attr"`
+}
+
+// atomAuthor is the author of a review.
+type atomAuthor struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+// WriteAtom writes the Atom feed of the business to w.
+func (f *Feed) WriteAtom(w io.Writer, businessID string) error {
+	b, err := f.snapshot(businessID)
+	if err != nil {
+		return err
+	}
+
+	af := atomFeed{
+		Xmlns:   atomNamespace,
+		ID:      "urn:yelp:business:" + businessID,
+		Title:   "Reviews of " + b.name,
+		Updated: b.updated.UTC().Format(time.RFC3339),
+		Link:    atomLink{Href: businessURL(businessID)},
+	}
+	for _, it := range b.items {
+		e := atomEntry{
+			ID:        "urn:yelp:review:" + it.review.ID,
+			Title:     it.title(),
+			Updated:   it.published.UTC().Format(time.RFC3339),
+			Published: it.published.UTC().Format(time.RFC3339),
+			Author:    atomAuthor{Name: it.review.User.Name, URI: it.review.User.ProfileURL},
+			Summary:   it.review.Text,
+		}
+		if it.review.URL != "" {
+			e.Link = &atomLink{Href: it.review.URL}
+		}
+		af.Entries = append(af.Entries, e)
+	}
+	return writeXML(w, af)
+}
@@ -0,0 +1,159 @@
+// Package feed renders the new reviews detected by a yelp.Refresher as RSS
+// and Atom feeds, one per watched business:
+//
+//	f := feed.New(50)
+//	r := yelp.NewRefresher(client, nil, time.Hour)
+//	r.Track(ids...)
+//	r.PublishTo(f)
+//	go r.Run(ctx, nil)
+//	http.Handle("/feeds/", http.StripPrefix("/feeds/", f))
+//
+// serves the reviews of a business at /feeds/<business id>.rss and
+// /feeds/<business id>.atom. The first refresh of a business only records
+// its reviews, so a feed starts with the reviews posted after it.
+package feed
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+// yelpTimeLayout is the layout of the creation time of Yelp reviews.
+const yelpTimeLayout = "2006-01-02 15:04:05"
+
+// ErrUnknownBusiness is returned when writing the feed of a business with no
+// review received.
+var ErrUnknownBusiness = errors.New("feed: unknown business")
+
+// Feed keeps the latest new reviews of every business and renders them. It
+// is a yelp.Publisher and an http.Handler.
+type Feed struct {
+	maxItems int
+
+	mu         sync.RWMutex
+	businesses map[string]*business
+}
+
+// business is the feed of a business.
+type business struct {
+	name    string
+	updated time.Time
+	items   []item
+}
+
+// item is a review in a feed.
+type item struct {
+	review    yelp.Review
+	published time.Time
+}
+
+var _ yelp.Publisher = (*Feed)(nil)
+
+// New returns an empty Feed keeping the latest maxItems reviews of every
+// business, 20 when not positive.
+func New(maxItems int) *Feed {
+	if maxItems <= 0 {
+		maxItems = 20
+	}
+	return &Feed{maxItems: maxItems, businesses: map[string]*business{}}
+}
+
+// Publish adds the review of a yelp.ChangeNewReview event to the feed of its
+// business. Other events are ignored.
+func (f *Feed) Publish(_ context.Context, e yelp.ChangeEvent) error {
+	if e.Kind != yelp.ChangeNewReview || e.Review == nil {
+		return nil
+	}
+	published, err := time.Parse(yelpTimeLayout, e.Review.TimeCreated)
+	if err != nil {
+		published = e.DetectedAt
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	b := f.businesses[e.BusinessID]
+	if b == nil {
+		b = &business{}
+		f.businesses[e.BusinessID] = b
+	}
+	b.name = e.BusinessName
+	b.updated = e.DetectedAt
+	b.items = append([]item{{review: *e.Review, published: published}}, b.items...)
+	if len(b.items) > f.maxItems {
+		b.items = b.items[:f.maxItems]
+	}
+	return nil
+}
+
+// snapshot returns a copy of the feed of the business.
+func (f *Feed) snapshot(businessID string) (business, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	b := f.businesses[businessID]
+	if b == nil {
+		return business{}, ErrUnknownBusiness
+	}
+	cp := *b
+	cp.items = append([]item(nil), b.items...)
+	return cp, nil
+}
+
+// ServeHTTP serves the RSS feed of a business at <business id>.rss and its
+// Atom feed at <business id>.atom.
+func (f *Feed) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/")
+	var write func(io.Writer, string) error
+	var contentType string
+	switch {
+	case strings.HasSuffix(name, ".rss"):
+		write, contentType = f.WriteRSS, "application/rss+xml; charset=utf-8"
+	case strings.HasSuffix(name, ".atom"):
+		write, contentType = f.WriteAtom, "application/atom+xml; charset=utf-8"
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	id := name[:strings.LastIndex(name, ".")]
+	if _, err := f.snapshot(id); err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	if err := write(w, id); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// businessURL returns the Yelp page of the business.
+func businessURL(businessID string) string {
+	return "https://www.yelp.com/biz/" + url.PathEscape(businessID)
+}
+
+// title returns the title of the item of a review.
+func (it item) title() string {
+	return fmt.Sprintf("%g stars by %s", it.review.Rating, it.review.User.Name)
+}
+
+// writeXML writes v to w as an XML document.
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,67 @@
+package feed
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+// rss is the root of an RSS 2.0 document.
+type rss struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+// rssChannel is the feed of a business.
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+// rssItem is a review.
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link,omitempty"`
+	Description string  `xml:"description"`
+	Author      string  `xml:"author,omitempty"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+// rssGUID is the id of a review.
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// WriteRSS writes the RSS 2.0 feed of the business to w.
+func (f *Feed) WriteRSS(w io.Writer, businessID string) error {
+	b, err := f.snapshot(businessID)
+	if err != nil {
+		return err
+	}
+
+	ch := rssChannel{
+		Title:       "Reviews of " + b.name,
+		Link:        businessURL(businessID),
+		Description: "New Yelp reviews of " + b.name,
+	}
+	if !b.updated.IsZero() {
+		ch.LastBuildDate = b.updated.Format(time.RFC1123Z)
+	}
+	for _, it := range b.items {
+		ch.Items = append(ch.Items, rssItem{
+			Title:       it.title(),
+			Link:        it.review.URL,
+			Description: it.review.Text,
+			Author:      it.review.User.Name,
+			GUID:        rssGUID{Value: "yelp-review-" + it.review.ID},
+			PubDate:     it.published.Format(time.RFC1123Z),
+		})
+	}
+	return writeXML(w, rss{Version: "2.0", Channel: ch})
+}
@@ -0,0 +1,87 @@
+package format
+
+import (
+	"strings"
+
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+const (
+	// maxDiscordEmbeds is the largest number of embeds Discord accepts in a
+	// message.
+	maxDiscordEmbeds = 10
+
+	// yelpRed is the color of the Yelp brand.
+	yelpRed = 0xD32323
+)
+
+// DiscordMessage is a Discord message made of embeds.
+type DiscordMessage struct {
+	Content string         `json:"content,omitempty"`
+	Embeds  []DiscordEmbed `json:"embeds"`
+}
+
+// DiscordEmbed is a Discord rich embed.
+type DiscordEmbed struct {
+	Title       string              `json:"title"`
+	URL         string              `json:"url,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Color       int                 `json:"color"`
+	Thumbnail   *DiscordImage       `json:"thumbnail,omitempty"`
+	Fields      []DiscordEmbedField `json:"fields,omitempty"`
+	Footer      *DiscordFooter      `json:"footer,omitempty"`
+}
+
+// DiscordImage is the image of an embed.
+type DiscordImage struct {
+	URL string `json:"url"`
+}
+
+// DiscordEmbedField is a field of an embed.
+type DiscordEmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+// DiscordFooter is the footer of an embed.
+type DiscordFooter struct {
+	Text string `json:"text"`
+}
+
+// Discord returns the first businesses of sr as a Discord message with an
+// embed per business, within the embed limit of Discord.
+func Discord(sr yelp.SearchResults) DiscordMessage {
+	msg := DiscordMessage{Embeds: []DiscordEmbed{}}
+	for _, b := range sr.Businesses {
+		if len(msg.Embeds) == maxDiscordEmbeds {
+			break
+		}
+		msg.Embeds = append(msg.Embeds, DiscordBusiness(b))
+	}
+	return msg
+}
+
+// DiscordBusiness returns the embed showing b.
+func DiscordBusiness(b yelp.Business) DiscordEmbed {
+	e := DiscordEmbed{
+		Title:       b.Name,
+		URL:         b.URL,
+		Description: ratingLine(b),
+		Color:       yelpRed,
+		Footer:      &DiscordFooter{Text: "Powered by Yelp"},
+	}
+	if b.ImageURL != "" {
+		e.Thumbnail = &DiscordImage{URL: b.ImageURL}
+	}
+	if c := categories(b); c != "" {
+		e.Fields = append(e.Fields, DiscordEmbedField{Name: "Categories", Value: c, Inline: true})
+	}
+	if len(b.Location.DisplayAddress) > 0 {
+		e.Fields = append(e.Fields, DiscordEmbedField{Name: "Address", Value: strings.Join(b.Location.DisplayAddress, "\n"), Inline: true})
+	}
+	if b.DisplayPhone != "" {
+		e.Fields = append(e.Fields, DiscordEmbedField{Name: "Phone", Value: b.DisplayPhone, Inline: true})
+	}
+	return e
+}
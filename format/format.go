@@ -0,0 +1,28 @@
+// Package format converts Yelp search results into chat messages: Slack Block
+// Kit blocks and Discord embeds. The returned types encode to the JSON
+// expected by the Slack and Discord APIs with encoding/json.
+package format
+
+import (
+	"strings"
+
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+// categories returns the titles of the categories of b separated by commas.
+func categories(b yelp.Business) string {
+	titles := make([]string, len(b.Categories))
+	for i, c := range b.Categories {
+		titles[i] = c.Title
+	}
+	return strings.Join(titles, ", ")
+}
+
+// ratingLine returns the stars, rating, review count and price of b.
+func ratingLine(b yelp.Business) string {
+	line := yelp.Stars(b.Rating) + " " + yelp.FloatString(b.Rating) + " (" + yelp.IntString(b.ReviewCount) + " reviews)"
+	if b.Price != "" {
+		line += " · " + b.Price
+	}
+	return line
+}
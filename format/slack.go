@@ -0,0 +1,91 @@
+package format
+
+import (
+	"strings"
+
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+// maxSlackBlocks is the largest number of blocks Slack accepts in a message.
+const maxSlackBlocks = 50
+
+// SlackMessage is a Slack message made of Block Kit blocks.
+type SlackMessage struct {
+	Text   string       `json:"text,omitempty"`
+	Blocks []SlackBlock `json:"blocks"`
+}
+
+// SlackBlock is a Block Kit layout block.
+type SlackBlock struct {
+	Type      string        `json:"type"`
+	Text      *SlackText    `json:"text,omitempty"`
+	Accessory *SlackElement `json:"accessory,omitempty"`
+	Elements  []SlackText   `json:"elements,omitempty"`
+}
+
+// SlackText is a Block Kit text object.
+type SlackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// SlackElement is a Block Kit image element.
+type SlackElement struct {
+	Type     string `json:"type"`
+	ImageURL string `json:"image_url"`
+	AltText  string `json:"alt_text"`
+}
+
+// Slack returns the businesses of sr as a Slack message with a section per
+// business, separated by dividers, within the block limit of Slack.
+func Slack(sr yelp.SearchResults) SlackMessage {
+	msg := SlackMessage{
+		Text:   yelp.IntString(int64(len(sr.Businesses))) + " businesses found",
+		Blocks: []SlackBlock{},
+	}
+	for _, b := range sr.Businesses {
+		blocks := SlackBusiness(b)
+		if len(msg.Blocks) > 0 {
+			blocks = append([]SlackBlock{{Type: "divider"}}, blocks...)
+		}
+		if len(msg.Blocks)+len(blocks) > maxSlackBlocks {
+			break
+		}
+		msg.Blocks = append(msg.Blocks, blocks...)
+	}
+	return msg
+}
+
+// SlackBusiness returns the blocks showing b: a section with its name, rating,
+// categories and image, and a context with its address.
+func SlackBusiness(b yelp.Business) []SlackBlock {
+	lines := []string{"*<" + b.URL + "|" + slackEscape(b.Name) + ">*", ratingLine(b)}
+	if c := categories(b); c != "" {
+		lines = append(lines, "_"+slackEscape(c)+"_")
+	}
+
+	section := SlackBlock{
+		Type: "section",
+		Text: &SlackText{Type: "mrkdwn", Text: strings.Join(lines, "\n")},
+	}
+	if b.ImageURL != "" {
+		section.Accessory = &SlackElement{Type: "image", ImageURL: b.ImageURL, AltText: b.Name}
+	}
+	blocks := []SlackBlock{section}
+
+	if len(b.Location.DisplayAddress) > 0 {
+		blocks = append(blocks, SlackBlock{
+			Type:     "context",
+			Elements: []SlackText{{Type: "plain_text", Text: strings.Join(b.Location.DisplayAddress, ", ")}},
+		})
+	}
+	return blocks
+}
+
+// slackEscaper escapes the control characters of Slack mrkdwn.
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// slackEscape escapes s to be shown as is in Slack mrkdwn.
+func slackEscape(s string) string {
+	return slackEscaper.Replace(s)
+}
@@ -0,0 +1,250 @@
+// Package gateway implements a simplified REST facade over a go-yelp client,
+// so frontend applications can query Yelp without holding the API key.
+//
+// Endpoints:
+//
+//	GET /v1/search                   search businesses
+//	GET /v1/businesses/{id}          business details
+//	GET /v1/businesses/{id}/reviews  review excerpts of a business
+//	GET /healthz                     reachability of Yelp
+//	GET /openapi.json                OpenAPI 3 document of the endpoints
+//
+// Responses are cached by the client when it is configured with a cache and
+// each caller, identified by its IP address, is rate limited.
+package gateway
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+// Options configures a Handler.
+type Options struct {
+	// RatePerSecond is the number of requests per second allowed to each
+	// caller, with bursts of up to Burst requests. Zero disables the limit.
+	RatePerSecond float64
+	Burst         int
+
+	// AllowedOrigin is sent as Access-Control-Allow-Origin when not empty.
+	AllowedOrigin string
+}
+
+// Handler serves the gateway endpoints.
+type Handler struct {
+	client  yelp.Client
+	opts    Options
+	limiter *limiter
+	mux     *http.ServeMux
+}
+
+// New returns a Handler answering with c.
+func New(c yelp.Client, opts Options) *Handler {
+	h := &Handler{
+		client: c,
+		opts:   opts,
+		mux:    http.NewServeMux(),
+	}
+	if opts.RatePerSecond > 0 {
+		h.limiter = newLimiter(opts.RatePerSecond, opts.Burst)
+	}
+
+	h.mux.HandleFunc("GET /v1/search", h.search)
+	h.mux.HandleFunc("GET /v1/businesses/{id}", h.business)
+	h.mux.HandleFunc("GET /v1/businesses/{id}/reviews", h.reviews)
+	h.mux.HandleFunc("GET /healthz", h.health)
+	h.mux.HandleFunc("GET /openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, OpenAPI())
+	})
+	return h
+}
+
+// ServeHTTP rate limits the caller and serves the endpoint.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.opts.AllowedOrigin != "" {
+		w.Header().Set("Access-Control-Allow-Origin", h.opts.AllowedOrigin)
+	}
+	if h.limiter != nil && !h.limiter.allow(callerIP(r)) {
+		writeJSON(w, http.StatusTooManyRequests, ErrorResponse{Error: "too many requests", Code: "RATE_LIMITED"})
+		return
+	}
+	h.mux.ServeHTTP(w, r)
+}
+
+// search serves GET /v1/search.
+func (h *Handler) search(w http.ResponseWriter, r *http.Request) {
+	so, err := searchOptions(r)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error(), Code: "VALIDATION_ERROR"})
+		return
+	}
+
+	sr, err := h.client.Search(r.Context(), so)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp := SearchResponse{Total: sr.Total, Businesses: []Business{}}
+	for _, b := range sr.Businesses {
+		resp.Businesses = append(resp.Businesses, toBusiness(b))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// business serves GET /v1/businesses/{id}.
+func (h *Handler) business(w http.ResponseWriter, r *http.Request) {
+	bo := yelp.BusinessOptions{Locale: queryPtr(r, "locale")}
+	b, err := h.client.BusinessByID(r.Context(), r.PathValue("id"), bo)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toBusiness(b))
+}
+
+// reviews serves GET /v1/businesses/{id}/reviews.
+func (h *Handler) reviews(w http.ResponseWriter, r *http.Request) {
+	ro := yelp.ReviewsOptions{Locale: queryPtr(r, "locale")}
+	rr, err := h.client.Reviews(r.Context(), r.PathValue("id"), ro)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp := ReviewsResponse{Total: rr.Total, Reviews: []Review{}}
+	for _, rv := range rr.Reviews {
+		resp.Reviews = append(resp.Reviews, toReview(rv))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// health serves GET /healthz.
+func (h *Handler) health(w http.ResponseWriter, r *http.Request) {
+	pr, _ := h.client.Ping(r.Context())
+	status := http.StatusOK
+	if !pr.Reachable || !pr.Authorized {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, HealthResponse{
+		Reachable:  pr.Reachable,
+		Authorized: pr.Authorized,
+		Remaining:  pr.RateLimit.Remaining,
+	})
+}
+
+// searchOptions returns the SearchOptions of the query of r.
+func searchOptions(r *http.Request) (yelp.SearchOptions, error) {
+	so := yelp.SearchOptions{
+		Term:       queryPtr(r, "term"),
+		Location:   queryPtr(r, "location"),
+		Categories: queryPtr(r, "categories"),
+		Locale:     queryPtr(r, "locale"),
+		SortBy:     queryPtr(r, "sort_by"),
+		Price:      queryPtr(r, "price"),
+	}
+
+	q := r.URL.Query()
+	if q.Get("latitude") != "" || q.Get("longitude") != "" {
+		lat, err := strconv.ParseFloat(q.Get("latitude"), 64)
+		if err != nil {
+			return so, errors.New("invalid latitude")
+		}
+		lng, err := strconv.ParseFloat(q.Get("longitude"), 64)
+		if err != nil {
+			return so, errors.New("invalid longitude")
+		}
+		so.Coordinates = &yelp.Coordinates{Latitude: lat, Longitude: lng}
+	}
+	for name, dst := range map[string]**int64{"radius": &so.Radius, "limit": &so.Limit, "offset": &so.Offset} {
+		if v := q.Get(name); v != "" {
+			i, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return so, errors.New("invalid " + name)
+			}
+			*dst = &i
+		}
+	}
+	if v := q.Get("open_now"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return so, errors.New("invalid open_now")
+		}
+		so.OpenNow = &b
+	}
+	if v := q.Get("attributes"); v != "" {
+		for _, a := range strings.Split(v, ",") {
+			so.Attributes = append(so.Attributes, yelp.Attribute(a))
+		}
+	}
+
+	if !so.IsValid() {
+		return so, errors.New("either location or latitude and longitude must be set")
+	}
+	return so, nil
+}
+
+// queryPtr returns the query parameter name of r, or nil when it is empty.
+func queryPtr(r *http.Request, name string) *string {
+	if v := r.URL.Query().Get(name); v != "" {
+		return &v
+	}
+	return nil
+}
+
+// callerIP returns the IP address of the caller of r.
+func callerIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// statusClientClosedRequest is the nginx status of requests canceled by the
+// caller.
+const statusClientClosedRequest = 499
+
+// writeError writes err as an ErrorResponse with a matching status, without
+// the details of the request made to Yelp.
+func writeError(w http.ResponseWriter, err error) {
+	if errors.Is(err, yelp.ErrQuotaExceeded) {
+		writeJSON(w, http.StatusTooManyRequests, ErrorResponse{Error: "quota exceeded", Code: "QUOTA_EXCEEDED"})
+		return
+	}
+
+	apiErr := &yelp.APIError{}
+	if !errors.As(err, &apiErr) {
+		switch yelp.ClassifyError(err) {
+		case yelp.ErrorClassCanceled:
+			writeJSON(w, statusClientClosedRequest, ErrorResponse{Error: "request canceled"})
+		case yelp.ErrorClassTimeout:
+			writeJSON(w, http.StatusGatewayTimeout, ErrorResponse{Error: "Yelp timed out"})
+		case yelp.ErrorClassNetwork:
+			writeJSON(w, http.StatusBadGateway, ErrorResponse{Error: "Yelp is unreachable"})
+		case yelp.ErrorClassDecode:
+			writeJSON(w, http.StatusBadGateway, ErrorResponse{Error: "invalid response from Yelp"})
+		default:
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: "internal error"})
+		}
+		return
+	}
+	status := http.StatusBadGateway
+	switch apiErr.StatusCode {
+	case http.StatusBadRequest, http.StatusNotFound, http.StatusTooManyRequests:
+		status = apiErr.StatusCode
+	}
+	writeJSON(w, status, ErrorResponse{Error: apiErr.Description, Code: apiErr.Code})
+}
+
+// writeJSON writes v as the JSON body of a response with status.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
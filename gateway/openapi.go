@@ -0,0 +1,164 @@
+package gateway
+
+import (
+	"reflect"
+	"strings"
+)
+
+// openAPIVersion is the version of the OpenAPI specification of the document.
+const openAPIVersion = "3.0.3"
+
+// param is a query or path parameter of an endpoint.
+type param struct {
+	name        string
+	in          string
+	typ         string
+	description string
+}
+
+// searchParams are the query parameters of the search endpoint.
+var searchParams = []param{
+	{"term", "query", "string", "Search term, e.g. \"food\" or \"restaurants\"."},
+	{"location", "query", "string", "Location to search in. Required unless latitude and longitude are set."},
+	{"latitude", "query", "number", "Latitude of the location to search from."},
+	{"longitude", "query", "number", "Longitude of the location to search from."},
+	{"radius", "query", "integer", "Search radius in meters, up to 40000."},
+	{"categories", "query", "string", "Comma separated category aliases."},
+	{"locale", "query", "string", "Locale of the results, e.g. \"en_US\"."},
+	{"limit", "query", "integer", "Number of businesses to return, up to 50."},
+	{"offset", "query", "integer", "Offset of the first business to return."},
+	{"sort_by", "query", "string", "One of best_match, rating, review_count or distance."},
+	{"price", "query", "string", "Comma separated price levels, e.g. \"1,2\"."},
+	{"open_now", "query", "boolean", "Only return businesses open now."},
+	{"attributes", "query", "string", "Comma separated attributes, e.g. \"wheelchair_accessible\"."},
+}
+
+// localeParam is the locale query parameter.
+var localeParam = param{"locale", "query", "string", "Locale of the results, e.g. \"en_US\"."}
+
+// idParam is the business id path parameter.
+var idParam = param{"id", "path", "string", "Id or alias of the business."}
+
+// OpenAPI returns the OpenAPI 3 document describing the gateway endpoints,
+// with the schemas of the responses generated from the Go types, ready to
+// be encoded with encoding/json.
+func OpenAPI() map[string]interface{} {
+	schemas := map[string]interface{}{}
+	for _, v := range []interface{}{SearchResponse{}, Business{}, ReviewsResponse{}, Review{}, HealthResponse{}, ErrorResponse{}} {
+		t := reflect.TypeOf(v)
+		schemas[t.Name()] = schemaOf(t)
+	}
+
+	return map[string]interface{}{
+		"openapi": openAPIVersion,
+		"info": map[string]interface{}{
+			"title":       "go-yelp gateway",
+			"description": "Simplified REST facade over the Yelp Fusion API.",
+			"version":     "1.0.0",
+		},
+		"paths": map[string]interface{}{
+			"/v1/search":                  operation("Search businesses", searchParams, "SearchResponse"),
+			"/v1/businesses/{id}":         operation("Get the details of a business", []param{idParam, localeParam}, "Business"),
+			"/v1/businesses/{id}/reviews": operation("Get the review excerpts of a business", []param{idParam, localeParam}, "ReviewsResponse"),
+			"/healthz":                    operation("Check Yelp is reachable", nil, "HealthResponse"),
+		},
+		"components": map[string]interface{}{
+			"schemas": schemas,
+		},
+	}
+}
+
+// operation returns the path item of a GET endpoint answering with the schema
+// named response.
+func operation(summary string, params []param, response string) map[string]interface{} {
+	parameters := []interface{}{}
+	for _, p := range params {
+		parameters = append(parameters, map[string]interface{}{
+			"name":        p.name,
+			"in":          p.in,
+			"required":    p.in == "path",
+			"description": p.description,
+			"schema":      map[string]interface{}{"type": p.typ},
+		})
+	}
+
+	return map[string]interface{}{
+		"get": map[string]interface{}{
+			"summary":    summary,
+			"parameters": parameters,
+			"responses": map[string]interface{}{
+				"200":     jsonResponse("OK", response),
+				"default": jsonResponse("Error", "ErrorResponse"),
+			},
+		},
+	}
+}
+
+// jsonResponse returns a response with a JSON body of the schema named schema.
+func jsonResponse(description, schema string) map[string]interface{} {
+	return map[string]interface{}{
+		"description": description,
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{
+				"schema": schemaRef(schema),
+			},
+		},
+	}
+}
+
+// schemaRef returns a reference to the schema named name.
+func schemaRef(name string) map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+}
+
+// schemaOf returns the JSON schema of t. Structs of this package are
+// referenced by name, fields without omitempty are required.
+func schemaOf(t reflect.Type) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number", "format": "double"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": fieldSchema(t.Elem())}
+	case reflect.Ptr:
+		return schemaOf(t.Elem())
+	case reflect.Struct:
+		properties := map[string]interface{}{}
+		required := []string{}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if !f.IsExported() || name == "-" {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			properties[name] = fieldSchema(f.Type)
+			if !strings.Contains(opts, "omitempty") {
+				required = append(required, name)
+			}
+		}
+		schema := map[string]interface{}{"type": "object", "properties": properties}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		return schema
+	}
+	return map[string]interface{}{}
+}
+
+// fieldSchema returns the schema of a field of type t, referencing the structs
+// of this package.
+func fieldSchema(t reflect.Type) map[string]interface{} {
+	if t.Kind() == reflect.Struct && t.PkgPath() == reflect.TypeOf(Business{}).PkgPath() {
+		return schemaRef(t.Name())
+	}
+	return schemaOf(t)
+}
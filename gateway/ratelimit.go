@@ -0,0 +1,77 @@
+package gateway
+
+import (
+	"sync"
+	"time"
+)
+
+// sweepInterval is the least time between two sweeps of the full buckets.
+const sweepInterval = time.Minute
+
+// limiter is a token bucket per caller.
+type limiter struct {
+	mu      sync.Mutex
+	rate    float64
+	burst   float64
+	buckets map[string]*bucket
+	swept   time.Time
+}
+
+// bucket holds the tokens of a caller.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// newLimiter returns a limiter allowing rate requests per second to each
+// caller, with bursts of up to burst requests.
+func newLimiter(rate float64, burst int) *limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &limiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: map[string]*bucket{},
+		swept:   time.Now(),
+	}
+}
+
+// allow takes a token from the bucket of caller, returning false when it is
+// empty.
+func (l *limiter) allow(caller string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(l.swept) >= sweepInterval {
+		l.sweep(now)
+	}
+	b, ok := l.buckets[caller]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[caller] = b
+	}
+	b.tokens += now.Sub(b.last).Seconds() * l.rate
+	if b.tokens > l.burst {
+		b.tokens = l.burst
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// sweep forgets the buckets idle long enough to be full again, which behave
+// as new ones, so the map does not grow forever.
+func (l *limiter) sweep(now time.Time) {
+	l.swept = now
+	for k, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, k)
+		}
+	}
+}
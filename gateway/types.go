@@ -0,0 +1,100 @@
+package gateway
+
+import (
+	"strings"
+
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+// Business is a business as returned by the gateway.
+type Business struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	URL         string   `json:"url"`
+	ImageURL    string   `json:"image_url,omitempty"`
+	Rating      float64  `json:"rating"`
+	ReviewCount int64    `json:"review_count"`
+	Price       string   `json:"price,omitempty"`
+	Phone       string   `json:"phone,omitempty"`
+	Categories  []string `json:"categories"`
+	Address     string   `json:"address"`
+	Latitude    float64  `json:"latitude"`
+	Longitude   float64  `json:"longitude"`
+	Distance    float64  `json:"distance,omitempty"`
+	IsClosed    bool     `json:"is_closed"`
+}
+
+// SearchResponse is the response of the search endpoint.
+type SearchResponse struct {
+	Total      int64      `json:"total"`
+	Businesses []Business `json:"businesses"`
+}
+
+// Review is a review excerpt as returned by the gateway.
+type Review struct {
+	ID          string  `json:"id"`
+	Rating      float64 `json:"rating"`
+	Text        string  `json:"text"`
+	URL         string  `json:"url"`
+	TimeCreated string  `json:"time_created"`
+	UserName    string  `json:"user_name"`
+}
+
+// ReviewsResponse is the response of the reviews endpoint.
+type ReviewsResponse struct {
+	Total   int64    `json:"total"`
+	Reviews []Review `json:"reviews"`
+}
+
+// HealthResponse is the response of the health endpoint.
+type HealthResponse struct {
+	Reachable  bool `json:"reachable"`
+	Authorized bool `json:"authorized"`
+	Remaining  int  `json:"remaining"`
+}
+
+// ErrorResponse is the response of any endpoint that failed.
+type ErrorResponse struct {
+	Error string `json:"error"`
+	Code  string `json:"code,omitempty"`
+}
+
+// toBusiness returns b as returned by the gateway.
+func toBusiness(b yelp.Business) Business {
+	categories := make([]string, len(b.Categories))
+	for i, c := range b.Categories {
+		categories[i] = c.Title
+	}
+	phone := b.DisplayPhone
+	if phone == "" {
+		phone = b.Phone
+	}
+	return Business{
+		ID:          b.ID,
+		Name:        b.Name,
+		URL:         b.URL,
+		ImageURL:    b.ImageURL,
+		Rating:      b.Rating,
+		ReviewCount: b.ReviewCount,
+		Price:       b.Price,
+		Phone:       phone,
+		Categories:  categories,
+		Address:     strings.Join(b.Location.DisplayAddress, ", "),
+		Latitude:    b.Coodinates.Latitude,
+		Longitude:   b.Coodinates.Longitude,
+		Distance:    b.Distance,
+		IsClosed:    b.IsClosed,
+	}
+}
+
+// toReview returns r as returned by the gateway.
+func toReview(r yelp.Review) Review {
+	return Review{
+		ID:          r.ID,
+		Rating:      r.Rating,
+		Text:        r.Text,
+		URL:         r.URL,
+		TimeCreated: r.TimeCreated,
+		UserName:    r.User.Name,
+	}
+}
@@ -0,0 +1,105 @@
+package match
+
+import "strings"
+
+// Address is a postal address split in components.
+type Address struct {
+	Street  string
+	City    string
+	State   string
+	ZipCode string
+	Country string
+}
+
+// streetAbbreviations maps street words to their usual abbreviation.
+var streetAbbreviations = map[string]string{
+	"street": "st", "avenue": "ave", "av": "ave", "boulevard": "blvd", "road": "rd",
+	"drive": "dr", "lane": "ln", "court": "ct", "place": "pl", "square": "sq",
+	"highway": "hwy", "parkway": "pkwy", "terrace": "ter", "circle": "cir",
+	"north": "n", "south": "s", "east": "e", "west": "w",
+	"northeast": "ne", "northwest": "nw", "southeast": "se", "southwest": "sw",
+}
+
+// unitWords introduce a unit number, dropped from streets with the number.
+var unitWords = map[string]bool{
+	"suite": true, "ste": true, "unit": true, "apt": true, "floor": true, "fl": true, "room": true, "rm": true,
+}
+
+// NormalizeStreet lowercases street, abbreviates street types and directions
+// and drops unit designators, so "123 North Main Street, Suite 4" and "123 N
+// Main St" compare equal.
+func NormalizeStreet(street string) string {
+	words := []string{}
+	toks := tokens(strings.ReplaceAll(street, "#", " unit "))
+	for i := 0; i < len(toks); i++ {
+		w := toks[i]
+		if unitWords[w] {
+			i++ // skip the unit number
+			continue
+		}
+		if abbr, ok := streetAbbreviations[w]; ok {
+			w = abbr
+		}
+		words = append(words, w)
+	}
+	return strings.Join(words, " ")
+}
+
+// AddressSimilarity returns how similar two addresses are, from 0 to 1. The
+// street counts the most and does not match when the house numbers differ;
+// the city and the zip code, or the state when a zip code is missing, make
+// up the rest. Missing components count as half a match.
+func AddressSimilarity(a, b Address) float64 {
+	street := 0.5
+	if sa, sb := NormalizeStreet(a.Street), NormalizeStreet(b.Street); sa != "" && sb != "" {
+		street = streetSimilarity(sa, sb)
+	}
+
+	city := component(a.City, b.City)
+	area := component(zip5(a.ZipCode), zip5(b.ZipCode))
+	if a.ZipCode == "" || b.ZipCode == "" {
+		area = component(a.State, b.State)
+	}
+	return 0.6*street + 0.2*city + 0.2*area
+}
+
+// streetSimilarity compares normalized streets.
+func streetSimilarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	fa, fb := strings.Fields(a), strings.Fields(b)
+	if isNumber(fa[0]) && isNumber(fb[0]) && fa[0] != fb[0] {
+		return 0
+	}
+	return dice(fa, fb)
+}
+
+// component returns 1 when a and b are equal once normalized, 0 when they
+// differ and 0.5 when one is missing.
+func component(a, b string) float64 {
+	na, nb := strings.Join(tokens(a), " "), strings.Join(tokens(b), " ")
+	switch {
+	case na == "" || nb == "":
+		return 0.5
+	case na == nb:
+		return 1
+	}
+	return 0
+}
+
+// zip5 returns the first five characters of a zip code, so ZIP+4 codes match
+// their ZIP code.
+func zip5(zip string) string {
+	zip = strings.TrimSpace(zip)
+	if len(zip) > 5 && zip[5] == '-' {
+		return zip[:5]
+	}
+	return zip
+}
+
+// isNumber returns true when s starts with a digit, as house numbers such
+// as "12b" do.
+func isNumber(s string) bool {
+	return s != "" && s[0] >= '0' && s[0] <= '9'
+}
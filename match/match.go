@@ -0,0 +1,161 @@
+// Package match compares business names and addresses written differently,
+// such as "Joe's Pizza Restaurant" and "JOES PIZZA", and scores how likely
+// two records are the same place. It has no dependency on the Yelp client
+// and is used by it to resolve merged businesses.
+package match
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Record is a business as known outside of Yelp, e.g. a row of a point of
+// sale export.
+type Record struct {
+	Name    string
+	Address Address
+	Phone   string
+}
+
+// Score returns how likely a and b are the same place, from 0 to 1. Names
+// weigh the most, then addresses; matching phone numbers raise the score.
+func Score(a, b Record) float64 {
+	score := 0.6*NameSimilarity(a.Name, b.Name) + 0.4*AddressSimilarity(a.Address, b.Address)
+	if pa, pb := phoneDigits(a.Phone), phoneDigits(b.Phone); pa != "" && pa == pb {
+		score += (1 - score) / 2
+	}
+	return score
+}
+
+// genericWords are dropped from names, as listings add or omit them freely.
+var genericWords = map[string]bool{
+	"the": true, "restaurant": true, "restaurants": true, "cafe": true, "bar": true,
+	"grill": true, "bistro": true, "eatery": true, "kitchen": true, "shop": true,
+	"store": true, "inc": true, "llc": true, "ltd": true, "co": true, "company": true,
+}
+
+// NormalizeName lowercases name, removes accents and punctuation, replaces
+// "&" with "and" and drops generic words such as "restaurant", "café" or
+// "inc", so "The Joe's Café & Bar" and "joes and" compare equal.
+func NormalizeName(name string) string {
+	words := []string{}
+	for _, w := range tokens(strings.ReplaceAll(name, "&", " and ")) {
+		if !genericWords[w] {
+			words = append(words, w)
+		}
+	}
+	if len(words) == 0 {
+		// Keep names only made of generic words, such as "The Kitchen".
+		return strings.Join(tokens(name), " ")
+	}
+	return strings.Join(words, " ")
+}
+
+// NameSimilarity returns how similar two business names are, from 0 to 1,
+// once normalized: the highest of the share of common words and the edit
+// distance similarity, so both reordered words and typos score high.
+func NameSimilarity(a, b string) float64 {
+	na, nb := NormalizeName(a), NormalizeName(b)
+	if na == "" || nb == "" {
+		return 0
+	}
+	if na == nb {
+		return 1
+	}
+	words := dice(strings.Fields(na), strings.Fields(nb))
+	edits := 1 - float64(levenshtein(na, nb))/float64(max(len([]rune(na)), len([]rune(nb))))
+	return max(words, edits)
+}
+
+// tokens returns the lowercased words of s without accents and punctuation.
+// Apostrophes are dropped so "Joe's" is "joes".
+func tokens(s string) []string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r == '\'' || r == '’':
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			sb.WriteRune(fold(r))
+		default:
+			sb.WriteRune(' ')
+		}
+	}
+	return strings.Fields(sb.String())
+}
+
+// accents maps accented lowercase latin letters to their base letter.
+var accents = map[rune]rune{
+	'à': 'a', 'á': 'a', 'â': 'a', 'ã': 'a', 'ä': 'a', 'å': 'a',
+	'ç': 'c', 'è': 'e', 'é': 'e', 'ê': 'e', 'ë': 'e',
+	'ì': 'i', 'í': 'i', 'î': 'i', 'ï': 'i', 'ñ': 'n',
+	'ò': 'o', 'ó': 'o', 'ô': 'o', 'õ': 'o', 'ö': 'o', 'ø': 'o',
+	'ù': 'u', 'ú': 'u', 'û': 'u', 'ü': 'u', 'ý': 'y', 'ÿ': 'y',
+}
+
+// fold returns r without its accent.
+func fold(r rune) rune {
+	if base, ok := accents[r]; ok {
+		return base
+	}
+	return r
+}
+
+// dice returns the Sørensen–Dice coefficient of two lists of words.
+func dice(a, b []string) float64 {
+	if len(a)+len(b) == 0 {
+		return 0
+	}
+	counts := map[string]int{}
+	for _, w := range a {
+		counts[w]++
+	}
+	common := 0
+	for _, w := range b {
+		if counts[w] > 0 {
+			counts[w]--
+			common++
+		}
+	}
+	return 2 * float64(common) / float64(len(a)+len(b))
+}
+
+// levenshtein returns the number of rune insertions, deletions and
+// substitutions turning a into b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+// phoneDigits returns the last 10 digits of phone, ignoring country codes
+// and formatting, or "" when it has fewer than 7 digits.
+func phoneDigits(phone string) string {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+	if len(digits) < 7 {
+		return ""
+	}
+	if len(digits) > 10 {
+		digits = digits[len(digits)-10:]
+	}
+	return digits
+}
@@ -0,0 +1,54 @@
+// Package publish provides yelp.Publisher adapters sending the changes
+// detected by a yelp.Refresher to Kafka or NATS. Events are encoded as JSON.
+//
+// The adapters do not import any client library. NATS takes the connection,
+// such as a *nats.Conn, through the NATSConn interface; Kafka takes a function
+// producing a message, a one-liner with any Kafka client:
+//
+//	w := &kafka.Writer{Addr: kafka.TCP("localhost:9092"), Topic: "yelp-changes"}
+//	p := publish.Kafka(func(ctx context.Context, key, value []byte) error {
+//		return w.WriteMessages(ctx, kafka.Message{Key: key, Value: value})
+//	})
+//	refresher.PublishTo(p)
+package publish
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+// NATSConn is the part of a NATS connection used to publish, implemented by
+// *nats.Conn.
+type NATSConn interface {
+	Publish(subject string, data []byte) error
+}
+
+// NATS returns a Publisher publishing events to subject followed by the kind
+// of the event, e.g. "yelp.changes.new_review" for subject "yelp.changes".
+func NATS(conn NATSConn, subject string) yelp.Publisher {
+	return yelp.PublisherFunc(func(ctx context.Context, e yelp.ChangeEvent) error {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		return conn.Publish(subject+"."+string(e.Kind), data)
+	})
+}
+
+// KafkaProducer produces a message with key and value to a Kafka topic.
+type KafkaProducer func(ctx context.Context, key, value []byte) error
+
+// Kafka returns a Publisher producing events with produce. Messages are keyed
+// by business id, so the events of a business keep their order within a
+// partition.
+func Kafka(produce KafkaProducer) yelp.Publisher {
+	return yelp.PublisherFunc(func(ctx context.Context, e yelp.ChangeEvent) error {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		return produce(ctx, []byte(e.BusinessID), data)
+	})
+}
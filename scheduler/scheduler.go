@@ -0,0 +1,155 @@
+// Package scheduler runs saved Yelp searches on cron schedules and reports
+// the businesses that appeared in, changed in or disappeared from their
+// results since the previous run, e.g. to notify users of new places
+// matching a search.
+//
+//	s := scheduler.New(client)
+//	err := s.Add("sushi", "0 9 * * *", yelp.SearchOptions{
+//		Term:     yelp.StringPtr("sushi"),
+//		Location: yelp.StringPtr("San Francisco, CA"),
+//	}, func(r scheduler.Report) {
+//		for _, b := range r.Changes.Added {
+//			log.Printf("new sushi place: %s", b.Name)
+//		}
+//	})
+//	go s.Run(ctx)
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+// Report is the outcome of a run of a saved search.
+type Report struct {
+	Name  string
+	RanAt time.Time
+
+	// Changes are the businesses added to, changed in and removed from the
+	// results since the previous run. It is empty on the first run.
+	Changes yelp.SearchDiff
+
+	// Err is the error of the search, if any. The previous results are kept
+	// when it is set.
+	Err error
+}
+
+// job is a saved search registered in a Scheduler.
+type job struct {
+	search   *yelp.SavedSearch
+	schedule Schedule
+	report   func(Report)
+	next     time.Time
+}
+
+// Scheduler runs saved searches on their schedules.
+type Scheduler struct {
+	client yelp.Client
+
+	mu     sync.Mutex
+	jobs   []*job
+	wakeup chan struct{}
+}
+
+// New returns a Scheduler searching with c.
+func New(c yelp.Client) *Scheduler {
+	return &Scheduler{
+		client: c,
+		wakeup: make(chan struct{}, 1),
+	}
+}
+
+// Add registers the search so, named name, to run on the cron schedule spec,
+// see Parse. report is called with the outcome of every run.
+func (s *Scheduler) Add(name, spec string, so yelp.SearchOptions, report func(Report)) error {
+	return s.AddSaved(yelp.NewSavedSearch(name, so), spec, report)
+}
+
+// AddSaved registers ss to run on the cron schedule spec, see Parse. report
+// is called with the outcome of every run. ss is updated by every run, so it
+// can be stored to resume from its last results.
+func (s *Scheduler) AddSaved(ss *yelp.SavedSearch, spec string, report func(Report)) error {
+	schedule, err := Parse(spec)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.jobs = append(s.jobs, &job{
+		search:   ss,
+		schedule: schedule,
+		report:   report,
+		next:     schedule.Next(time.Now()),
+	})
+	s.mu.Unlock()
+
+	select {
+	case s.wakeup <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Run runs the saved searches when they are due until ctx is done. Searches
+// due at the same time run one after the other.
+func (s *Scheduler) Run(ctx context.Context) error {
+	for {
+		s.mu.Lock()
+		var next time.Time
+		for _, j := range s.jobs {
+			if !j.next.IsZero() && (next.IsZero() || j.next.Before(next)) {
+				next = j.next
+			}
+		}
+		s.mu.Unlock()
+
+		var due <-chan time.Time
+		var timer *time.Timer
+		if !next.IsZero() {
+			timer = time.NewTimer(time.Until(next))
+			due = timer.C
+		}
+
+		select {
+		case <-due:
+			s.runDue(ctx, time.Now())
+		case <-s.wakeup:
+		case <-ctx.Done():
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// runDue runs the jobs due at now and schedules their next run.
+func (s *Scheduler) runDue(ctx context.Context, now time.Time) {
+	s.mu.Lock()
+	due := []*job{}
+	for _, j := range s.jobs {
+		if !j.next.IsZero() && !j.next.After(now) {
+			due = append(due, j)
+			j.next = j.schedule.Next(now)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, j := range due {
+		r := j.run(ctx, s.client, now)
+		if j.report != nil {
+			j.report(r)
+		}
+	}
+}
+
+// run runs the saved search.
+func (j *job) run(ctx context.Context, c yelp.Client, now time.Time) Report {
+	changes, err := j.search.Run(ctx, c)
+	return Report{Name: j.search.Name, RanAt: now, Changes: changes, Err: err}
+}
@@ -0,0 +1,113 @@
+// Package redisquota provides a yelp.QuotaStore keeping the counters in
+// Redis, so the replicas of a service sharing an API key coordinate their
+// daily quota:
+//
+//	qm := yelp.NewQuotaManagerWithStore(nil, redisquota.New(redisquota.EvalFunc(
+//		func(ctx context.Context, script string, keys []string, args ...any) (any, error) {
+//			return rdb.Eval(ctx, script, keys, args...).Result()
+//		})))
+//	qm.SetDailyLimit(5000)
+//	c, err := yelp.New(nil, apiKey, yelp.WithQuotaManager(qm))
+//
+// The package does not import any client library. The Redis connection is
+// used through the Evaler interface, a one-liner with any Redis client.
+package redisquota
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+// Evaler runs a Lua script on Redis, returning its reply: nil, an int64, a
+// string or a slice of them.
+type Evaler interface {
+	Eval(ctx context.Context, script string, keys []string, args ...any) (any, error)
+}
+
+// EvalFunc is a function implementing Evaler.
+type EvalFunc func(ctx context.Context, script string, keys []string, args ...any) (any, error)
+
+// Eval calls f.
+func (f EvalFunc) Eval(ctx context.Context, script string, keys []string, args ...any) (any, error) {
+	return f(ctx, script, keys, args...)
+}
+
+// incrementScript increments a counter and sets its expiry in one step, so a
+// counter is never left without expiry.
+const incrementScript = `local n = redis.call('INCR', KEYS[1])
+redis.call('PEXPIREAT', KEYS[1], ARGV[1])
+return n`
+
+// countScript returns the value of a counter, 0 when it does not exist, so
+// clients reporting nil replies as errors are not tripped.
+const countScript = `return tonumber(redis.call('GET', KEYS[1]) or '0')`
+
+// decrementScript decrements a counter unless it expired, so a refund never
+// leaves a counter without expiry.
+const decrementScript = `if redis.call('EXISTS', KEYS[1]) == 1 then
+	return redis.call('DECR', KEYS[1])
+end
+return 0`
+
+// Store is a yelp.QuotaStore keeping the counters in Redis.
+type Store struct {
+	redis  Evaler
+	prefix string
+}
+
+var _ yelp.QuotaStore = (*Store)(nil)
+
+// New returns a Store running its commands with redis.
+func New(redis Evaler) *Store {
+	return &Store{redis: redis}
+}
+
+// WithPrefix returns a copy of s prefixing its keys with prefix, e.g. to share
+// a Redis database between services using different API keys.
+func (s *Store) WithPrefix(prefix string) *Store {
+	cp := *s
+	cp.prefix = prefix
+	return &cp
+}
+
+// Increment adds one to the counter of key, expiring it at expiresAt.
+func (s *Store) Increment(ctx context.Context, key string, expiresAt time.Time) (int64, error) {
+	reply, err := s.redis.Eval(ctx, incrementScript, []string{s.prefix + key}, expiresAt.UnixMilli())
+	if err != nil {
+		return 0, err
+	}
+	return toInt64(reply)
+}
+
+// Count returns the value of the counter of key, zero when it has none.
+func (s *Store) Count(ctx context.Context, key string) (int64, error) {
+	reply, err := s.redis.Eval(ctx, countScript, []string{s.prefix + key})
+	if err != nil {
+		return 0, err
+	}
+	return toInt64(reply)
+}
+
+// Decrement subtracts one from the counter of key, unless it expired.
+func (s *Store) Decrement(ctx context.Context, key string) error {
+	_, err := s.redis.Eval(ctx, decrementScript, []string{s.prefix + key})
+	return err
+}
+
+// toInt64 returns the integer of a script reply.
+func toInt64(reply any) (int64, error) {
+	switch v := reply.(type) {
+	case int64:
+		return v, nil
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	case []byte:
+		return strconv.ParseInt(string(v), 10, 64)
+	default:
+		return 0, fmt.Errorf("redisquota: unexpected reply %T", reply)
+	}
+}
@@ -0,0 +1,271 @@
+// Package sqlstore stores mirrored Yelp businesses and reviews in a Postgres
+// or SQLite database through database/sql. The driver is not imported by the
+// package; open the *sql.DB with the driver of your choice.
+//
+// A Store implements yelp.Store, so a yelp.Refresher can mirror businesses
+// into the database:
+//
+//	s := sqlstore.New(db, sqlstore.Postgres)
+//	if err := s.Migrate(ctx); err != nil {
+//		return err
+//	}
+//	r := yelp.NewRefresher(client, s, time.Hour)
+//
+// It implements yelp.SyncStore too, so the stored businesses can be kept up
+// to date with yelp.Sync.
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ivancevich/go-yelp/export"
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+// Dialect is the SQL dialect of the database.
+type Dialect int
+
+const (
+	// Postgres is PostgreSQL 9.5 or later.
+	Postgres Dialect = iota
+
+	// SQLite is SQLite 3.24 or later.
+	SQLite
+)
+
+// placeholder returns the placeholder of the nth argument of a statement,
+// starting at 1.
+func (d Dialect) placeholder(n int) string {
+	if d == Postgres {
+		return "$" + strconv.Itoa(n)
+	}
+	return "?"
+}
+
+// migrations are the statements creating the schema, in order. Applied
+// migrations are recorded in schema_migrations; new ones are only ever
+// appended.
+var migrations = []string{
+	`CREATE TABLE businesses (
+		id TEXT PRIMARY KEY,
+		alias TEXT NOT NULL,
+		name TEXT NOT NULL,
+		url TEXT NOT NULL,
+		image_url TEXT NOT NULL,
+		phone TEXT NOT NULL,
+		is_claimed BOOLEAN NOT NULL,
+		is_closed BOOLEAN NOT NULL,
+		price TEXT NOT NULL,
+		rating DOUBLE PRECISION NOT NULL,
+		review_count BIGINT NOT NULL,
+		categories TEXT NOT NULL,
+		latitude DOUBLE PRECISION NOT NULL,
+		longitude DOUBLE PRECISION NOT NULL,
+		address TEXT NOT NULL,
+		city TEXT NOT NULL,
+		state TEXT NOT NULL,
+		zip_code TEXT NOT NULL,
+		country TEXT NOT NULL,
+		data TEXT NOT NULL,
+		updated_at TIMESTAMP NOT NULL
+	)`,
+	`CREATE TABLE reviews (
+		id TEXT PRIMARY KEY,
+		business_id TEXT NOT NULL,
+		rating DOUBLE PRECISION NOT NULL,
+		text TEXT NOT NULL,
+		time_created TEXT NOT NULL,
+		url TEXT NOT NULL,
+		user_id TEXT NOT NULL,
+		user_name TEXT NOT NULL,
+		updated_at TIMESTAMP NOT NULL
+	)`,
+	`CREATE INDEX reviews_business_id ON reviews (business_id)`,
+	`CREATE TABLE sync_entries (
+		id TEXT PRIMARY KEY,
+		synced_at TIMESTAMP NOT NULL,
+		deleted BOOLEAN NOT NULL
+	)`,
+}
+
+// Store stores businesses and reviews in a database.
+type Store struct {
+	db      *sql.DB
+	dialect Dialect
+}
+
+// New returns a Store using db, a database of the given dialect.
+func New(db *sql.DB, dialect Dialect) *Store {
+	return &Store{db: db, dialect: dialect}
+}
+
+// Migrate creates or updates the schema, applying every migration not
+// applied yet in its own transaction.
+func (s *Store) Migrate(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY)`)
+	if err != nil {
+		return err
+	}
+
+	var applied int
+	err = s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM schema_migrations`).Scan(&applied)
+	if err != nil {
+		return err
+	}
+
+	for version := applied; version < len(migrations); version++ {
+		err := s.inTx(ctx, func(tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, migrations[version]); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version) VALUES (`+s.dialect.placeholder(1)+`)`, version+1)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpsertBusinesses inserts the businesses, or updates them when they are
+// already stored, in a single transaction.
+func (s *Store) UpsertBusinesses(ctx context.Context, businesses []yelp.Business) error {
+	columns := []string{"id", "alias", "name", "url", "image_url", "phone", "is_claimed", "is_closed",
+		"price", "rating", "review_count", "categories", "latitude", "longitude", "address", "city",
+		"state", "zip_code", "country", "data", "updated_at"}
+	query := s.upsert("businesses", columns)
+	now := time.Now().UTC()
+
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(ctx, query)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for _, b := range businesses {
+			data, err := json.Marshal(b)
+			if err != nil {
+				return err
+			}
+			r := export.NewBusinessRecord(b)
+			_, err = stmt.ExecContext(ctx, r.ID, r.Alias, r.Name, r.URL, r.ImageURL, r.Phone, r.IsClaimed, r.IsClosed,
+				r.Price, r.Rating, r.ReviewCount, strings.Join(r.Categories, ","), r.Latitude, r.Longitude, r.Address, r.City,
+				r.State, r.ZipCode, r.Country, string(data), now)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// UpsertReviews inserts the reviews of the business with id businessID, or
+// updates them when they are already stored, in a single transaction.
+func (s *Store) UpsertReviews(ctx context.Context, businessID string, reviews []yelp.Review) error {
+	columns := []string{"id", "business_id", "rating", "text", "time_created", "url", "user_id", "user_name", "updated_at"}
+	query := s.upsert("reviews", columns)
+	now := time.Now().UTC()
+
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(ctx, query)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for _, rv := range reviews {
+			r := export.NewReviewRecord(businessID, rv)
+			_, err := stmt.ExecContext(ctx, r.ID, r.BusinessID, r.Rating, r.Text, r.TimeCreated, r.URL, r.UserID, r.UserName, now)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SyncEntries returns the sync entries of the stored businesses. The ETag of
+// a business is the Fingerprint of its stored data.
+func (s *Store) SyncEntries(ctx context.Context) ([]yelp.SyncEntry, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT b.id, b.data, s.synced_at, s.deleted
+		FROM businesses b LEFT JOIN sync_entries s ON s.id = b.id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []yelp.SyncEntry{}
+	for rows.Next() {
+		var data string
+		var syncedAt sql.NullTime
+		var deleted sql.NullBool
+		e := yelp.SyncEntry{}
+		if err := rows.Scan(&e.ID, &data, &syncedAt, &deleted); err != nil {
+			return nil, err
+		}
+		b := yelp.Business{}
+		if err := json.Unmarshal([]byte(data), &b); err != nil {
+			return nil, err
+		}
+		e.ETag, e.SyncedAt, e.Deleted = b.Fingerprint(), syncedAt.Time, deleted.Bool
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// PutSyncEntries inserts the sync entries, or updates them when they are
+// already stored, in a single transaction. Their ETags are not stored as
+// they follow the stored businesses.
+func (s *Store) PutSyncEntries(ctx context.Context, entries []yelp.SyncEntry) error {
+	query := s.upsert("sync_entries", []string{"id", "synced_at", "deleted"})
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(ctx, query)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for _, e := range entries {
+			if _, err := stmt.ExecContext(ctx, e.ID, e.SyncedAt.UTC(), e.Deleted); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// upsert returns the statement inserting a row in table, or updating every
+// column but the first, its primary key, on conflict.
+func (s *Store) upsert(table string, columns []string) string {
+	placeholders := make([]string, len(columns))
+	for i := range columns {
+		placeholders[i] = s.dialect.placeholder(i + 1)
+	}
+	updates := make([]string, 0, len(columns)-1)
+	for _, c := range columns[1:] {
+		updates = append(updates, c+" = excluded."+c)
+	}
+	return "INSERT INTO " + table + " (" + strings.Join(columns, ", ") + ") VALUES (" + strings.Join(placeholders, ", ") +
+		") ON CONFLICT (" + columns[0] + ") DO UPDATE SET " + strings.Join(updates, ", ")
+}
+
+// inTx calls fn in a transaction, committed when fn succeeds and rolled back
+// otherwise.
+func (s *Store) inTx(ctx context.Context, fn func(*sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,112 @@
+package yelp
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Attribute is a value accepted by the attributes filter of the Search API.
+type Attribute string
+
+// Available attributes for the Search API.
+const (
+	AttributeHotAndNew              Attribute = "hot_and_new"
+	AttributeRequestAQuote          Attribute = "request_a_quote"
+	AttributeReservation            Attribute = "reservation"
+	AttributeWaitlistReservation    Attribute = "waitlist_reservation"
+	AttributeDeals                  Attribute = "deals"
+	AttributeGenderNeutralRestrooms Attribute = "gender_neutral_restrooms"
+	AttributeOpenToAll              Attribute = "open_to_all"
+	AttributeWheelchairAccessible   Attribute = "wheelchair_accessible"
+	AttributeLikedByVegetarians     Attribute = "liked_by_vegetarians"
+	AttributeOutdoorSeating         Attribute = "outdoor_seating"
+	AttributeParkingGarage          Attribute = "parking_garage"
+	AttributeParkingLot             Attribute = "parking_lot"
+	AttributeParkingStreet          Attribute = "parking_street"
+	AttributeParkingValet           Attribute = "parking_valet"
+	AttributeParkingBike            Attribute = "parking_bike"
+	AttributeRestaurantsDelivery    Attribute = "restaurants_delivery"
+	AttributeRestaurantsTakeout     Attribute = "restaurants_takeout"
+	AttributeWifiFree               Attribute = "wifi_free"
+	AttributeWifiPaid               Attribute = "wifi_paid"
+)
+
+// AttributesString returns attrs as the comma separated list expected by the
+// Search API.
+func AttributesString(attrs []Attribute) string {
+	strs := make([]string, len(attrs))
+	for i, a := range attrs {
+		strs[i] = string(a)
+	}
+	return strings.Join(strs, ",")
+}
+
+// Attributes are the attributes of a business returned by the Business API.
+// A nil field means Yelp did not report the attribute for the business. Every
+// attribute, including those without a typed field, is available in Raw.
+type Attributes struct {
+	GenderNeutralRestrooms *bool   `json:"gender_neutral_restrooms"`
+	OpenToAll              *bool   `json:"open_to_all"`
+	WheelchairAccessible   *bool   `json:"wheelchair_accessible"`
+	LikedByVegetarians     *bool   `json:"liked_by_vegetarians"`
+	OutdoorSeating         *bool   `json:"outdoor_seating"`
+	WaitlistReservation    *bool   `json:"waitlist_reservation"`
+	BusinessTempClosed     *bool   `json:"business_temp_closed"`
+	Open24Hours            *bool   `json:"open24_hours"`
+	MenuURL                *string `json:"menu_url"`
+
+	// Service offerings
+	RestaurantsDelivery      *bool   `json:"restaurants_delivery"`
+	RestaurantsTakeOut       *bool   `json:"restaurants_take_out"`
+	RestaurantsReservations  *bool   `json:"restaurants_reservations"`
+	RestaurantsGoodForGroups *bool   `json:"restaurants_good_for_groups"`
+	Caters                   *bool   `json:"caters"`
+	DriveThru                *bool   `json:"drive_thru"`
+	GoodForKids              *bool   `json:"good_for_kids"`
+	DogsAllowed              *bool   `json:"dogs_allowed"`
+	HappyHour                *bool   `json:"happy_hour"`
+	HasTV                    *bool   `json:"has_tv"`
+	WiFi                     *string `json:"wi_fi"`
+	Alcohol                  *string `json:"alcohol"`
+	NoiseLevel               *string `json:"noise_level"`
+
+	// COVID-era offerings
+	MasksRequired            *bool `json:"masks_required"`
+	StaffWearsMasks          *bool `json:"staff_wears_masks"`
+	SociallyDistancedSeating *bool `json:"socially_distanced_seating"`
+	CurbsidePickup           *bool `json:"curbside_pickup"`
+	VirtualServiceOfferings  *bool `json:"virtual_service_offerings"`
+
+	Raw map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the typed attributes and keeps every attribute in Raw.
+func (a *Attributes) UnmarshalJSON(data []byte) error {
+	type attributes Attributes
+	typed := attributes{}
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return err
+	}
+	raw := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*a = Attributes(typed)
+	a.Raw = raw
+	return nil
+}
+
+// LookupBool returns the value of the named boolean attribute. ok is false
+// when the attribute is missing, null or not a boolean.
+func (a Attributes) LookupBool(name string) (value bool, ok bool) {
+	err := json.Unmarshal(a.Raw[name], &value)
+	return value, err == nil && string(a.Raw[name]) != "null"
+}
+
+// LookupString returns the value of the named string attribute. ok is false
+// when the attribute is missing, null or not a string.
+func (a Attributes) LookupString(name string) (value string, ok bool) {
+	err := json.Unmarshal(a.Raw[name], &value)
+	return value, err == nil && string(a.Raw[name]) != "null"
+}
@@ -0,0 +1,49 @@
+package yelp
+
+import "html/template"
+
+const (
+	// yelpURL is the home page of Yelp
+	yelpURL = "https://www.yelp.com"
+
+	// attributionText is the text of the attribution to Yelp
+	attributionText = "Powered by Yelp"
+)
+
+// YelpLogoURL is the URL of the Yelp logo of the brand kit, as hosted by the
+// application. The attribution markup shows it when set and a text link
+// otherwise.
+var YelpLogoURL = ""
+
+// AttributionText returns the attribution to show along with Yelp content
+// where markup is not available.
+func AttributionText() string {
+	return attributionText
+}
+
+// AttributionHTML returns the Yelp logo linking back to the Yelp page of b,
+// which Yelp's display requirements ask for next to the business' data.
+func AttributionHTML(b Business) template.HTML {
+	link := b.URL
+	if link == "" {
+		link = yelpURL
+	}
+	return attributionHTML(link)
+}
+
+// ResultsAttributionHTML returns the Yelp logo linking to Yelp,
+// to show once along with a list of businesses.
+func ResultsAttributionHTML() template.HTML {
+	return attributionHTML(yelpURL)
+}
+
+// attributionHTML returns the Yelp logo, or the attribution text, linking to
+// link.
+func attributionHTML(link string) template.HTML {
+	content := attributionText
+	if YelpLogoURL != "" {
+		content = `<img src="` + template.HTMLEscapeString(YelpLogoURL) + `" alt="` + attributionText + `" height="20">`
+	}
+	return template.HTML(`<a class="yelp-attribution" href="` + template.HTMLEscapeString(link) +
+		`" target="_blank" rel="noopener">` + content + `</a>`)
+}
@@ -0,0 +1,85 @@
+package yelp
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// batchWorkers is the number of requests of a batch operation sent at the
+// same time.
+const batchWorkers = 4
+
+// WithDeterministicOrder makes batch operations, such as BusinessesByIDs and
+// TileSearch, send their requests one at a time in a fixed order, so recorded
+// requests and golden files are stable across runs. It is meant for tests.
+func WithDeterministicOrder() Option {
+	return func(c *client) {
+		c.deterministic = true
+	}
+}
+
+// forEach calls fn with every index up to n, from batchWorkers goroutines, or
+// in order from the calling one in deterministic mode, counting the calls as
+// steps of pt. It stops starting calls once ctx is done.
+func (c *client) forEach(ctx context.Context, pt *ProgressTracker, n int, fn func(i int) error) {
+	workers := batchWorkers
+	if c.deterministic {
+		workers = 1
+	}
+	runBatch(ctx, pt, workers, n, fn)
+}
+
+// runBatch calls fn with every index up to n, from workers goroutines, or in
+// order from the calling one when workers is 1 or less, counting the calls as
+// steps of pt. It stops starting calls once ctx is done.
+func runBatch(ctx context.Context, pt *ProgressTracker, workers, n int, fn func(i int) error) {
+	pt.Add(n)
+	if workers <= 1 {
+		for i := 0; i < n && ctx.Err() == nil; i++ {
+			pt.Step(fn(i))
+		}
+		return
+	}
+
+	var wg sync.WaitGroup
+	work := make(chan int)
+	for w := 0; w < workers && w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				pt.Step(fn(i))
+			}
+		}()
+	}
+	for i := 0; i < n && ctx.Err() == nil; i++ {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+}
+
+// BusinessesByIDs looks for the businesses with the given ids, several at a
+// time, reporting progress as set with WithProgress. The businesses found are
+// returned in the order of ids; a business failing to load does not stop the
+// others and the errors are joined.
+func (c *client) BusinessesByIDs(ctx context.Context, ids []string, bo BusinessOptions) ([]Business, error) {
+	found := make([]Business, len(ids))
+	errs := make([]error, len(ids))
+	c.forEach(ctx, progressFromContext(ctx), len(ids), func(i int) error {
+		found[i], errs[i] = c.BusinessByID(ctx, ids[i], bo)
+		return errs[i]
+	})
+
+	businesses := []Business{}
+	for i := range ids {
+		if errs[i] == nil && found[i].ID != "" {
+			businesses = append(businesses, found[i])
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return businesses, err
+	}
+	return businesses, ctx.Err()
+}
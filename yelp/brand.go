@@ -0,0 +1,58 @@
+package yelp
+
+import (
+	"context"
+	"strings"
+	"unicode"
+)
+
+// maxSearchWindow is the largest offset plus limit accepted by the Search API.
+const maxSearchWindow = 1000
+
+// BrandLocations returns every location of the chain called name found by
+// searching it in region, e.g. "San Francisco, CA". Results are paginated
+// and kept when their normalized name is the normalized brand name, or
+// starts with it followed by a word, such as "Starbucks Reserve" for
+// "Starbucks". Each business is returned once.
+func (c *client) BrandLocations(ctx context.Context, name string, region string) ([]Business, error) {
+	brand := normalizeName(name)
+	seen := map[string]bool{}
+	locations := []Business{}
+
+	p := SearchPages(c, SearchOptions{
+		Term:     StringPtr(name),
+		Location: StringPtr(region),
+	})
+	for p.HasNext() {
+		businesses, err := p.Next(ctx)
+		if err != nil {
+			return locations, err
+		}
+
+		for _, b := range businesses {
+			n := normalizeName(b.Name)
+			if seen[b.ID] || (n != brand && !strings.HasPrefix(n, brand+" ")) {
+				continue
+			}
+			seen[b.ID] = true
+			locations = append(locations, b)
+		}
+	}
+	return locations, nil
+}
+
+// normalizeName lowercases name, drops apostrophes and replaces any other
+// punctuation with spaces, so "McDonald's" and "MCDONALDS" compare equal.
+func normalizeName(name string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r == '\'' || r == '’':
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
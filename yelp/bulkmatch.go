@@ -0,0 +1,235 @@
+package yelp
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ivancevich/go-yelp/match"
+)
+
+// defaultMatchCountry is the country of match inputs without one.
+const defaultMatchCountry = "US"
+
+// searchFallbackLimit is the number of search results scored when Business
+// Match finds no good candidate.
+const searchFallbackLimit = 10
+
+// MatchInput is a business to find on Yelp, e.g. a point of sale location.
+type MatchInput struct {
+	Name    string
+	Address string
+	City    string
+	State   string
+	ZipCode string
+	Country string
+	Phone   string
+}
+
+// MatchMethod is how a MatchResult was found.
+type MatchMethod string
+
+// Available match methods.
+const (
+	MatchBusinessMatch MatchMethod = "business_match"
+	MatchSearch        MatchMethod = "search"
+	MatchPhone         MatchMethod = "phone"
+	MatchNone          MatchMethod = "none"
+)
+
+// MatchResult is the Yelp business found for a MatchInput.
+type MatchResult struct {
+	Input    MatchInput
+	Business Business
+	Method   MatchMethod
+
+	// Confidence is how likely Business is the input, from 0 to 1, see
+	// match.Score.
+	Confidence float64
+
+	// Err is the error of the lookups, if any.
+	Err error
+}
+
+// Matched returns true when a business was found.
+func (mr MatchResult) Matched() bool {
+	return mr.Business.ID != ""
+}
+
+// record returns mi as a match.Record.
+func (mi MatchInput) record() match.Record {
+	return match.Record{
+		Name: mi.Name,
+		Address: match.Address{
+			Street:  mi.Address,
+			City:    mi.City,
+			State:   mi.State,
+			ZipCode: mi.ZipCode,
+			Country: mi.Country,
+		},
+		Phone: mi.Phone,
+	}
+}
+
+// BulkMatch finds the Yelp business of every input, several at a time,
+// reporting progress as set with WithProgress. Each input is looked up with
+// the Business Match API, then by phone and with a search of its name around
+// its address while no candidate scores minConfidence. Results are in the
+// order of inputs; candidates scoring less than minConfidence are left out.
+func (c *client) BulkMatch(ctx context.Context, inputs []MatchInput, minConfidence float64) []MatchResult {
+	results := make([]MatchResult, len(inputs))
+	c.forEach(ctx, progressFromContext(ctx), len(inputs), func(i int) error {
+		results[i] = c.matchOne(ctx, inputs[i], minConfidence)
+		return results[i].Err
+	})
+	for i := range results {
+		if results[i].Method == "" {
+			results[i] = MatchResult{Input: inputs[i], Method: MatchNone, Err: ctx.Err()}
+		}
+	}
+	return results
+}
+
+// matchOne finds the business of in.
+func (c *client) matchOne(ctx context.Context, in MatchInput, minConfidence float64) MatchResult {
+	if in.Country == "" {
+		in.Country = defaultMatchCountry
+	}
+	best := MatchResult{Input: in, Method: MatchNone}
+	want := in.record()
+	var errs []error
+	consider := func(method MatchMethod, candidates []Business, err error) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+		for _, b := range candidates {
+			if score := match.Score(want, matchRecord(b)); score >= minConfidence && score > best.Confidence {
+				best.Business, best.Method, best.Confidence = b, method, score
+			}
+		}
+	}
+
+	if in.Name != "" && in.Address != "" && in.City != "" && in.State != "" {
+		mo := MatchOptions{
+			Name:     StringPtr(in.Name),
+			Address1: StringPtr(in.Address),
+			City:     StringPtr(in.City),
+			State:    StringPtr(in.State),
+			Country:  StringPtr(in.Country),
+		}
+		if in.ZipCode != "" {
+			mo.ZipCode = StringPtr(in.ZipCode)
+		}
+		if in.Phone != "" {
+			mo.Phone = StringPtr(in.Phone)
+		}
+		candidates, err := c.BusinessMatch(ctx, mo)
+		consider(MatchBusinessMatch, candidates, err)
+	}
+
+	if !best.Matched() && in.Phone != "" {
+		sr, err := c.SearchByPhone(ctx, in.Phone)
+		if errors.Is(err, ErrInvalidPhone) {
+			err = nil
+		}
+		consider(MatchPhone, sr.Businesses, err)
+	}
+
+	location := strings.Join(nonEmpty(in.Address, in.City, in.State, in.ZipCode), ", ")
+	if !best.Matched() && in.Name != "" && location != "" {
+		sr, err := c.Search(ctx, SearchOptions{
+			Term:     StringPtr(in.Name),
+			Location: StringPtr(location),
+			Limit:    Int64Ptr(searchFallbackLimit),
+		})
+		consider(MatchSearch, sr.Businesses, err)
+	}
+
+	if !best.Matched() {
+		best.Err = errors.Join(errs...)
+	}
+	return best
+}
+
+// nonEmpty returns the non empty strings of strs.
+func nonEmpty(strs ...string) []string {
+	out := []string{}
+	for _, s := range strs {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// matchColumns are the CSV columns of a MatchInput and their aliases.
+var matchColumns = map[string]string{
+	"name": "name", "address": "address", "address1": "address", "street": "address",
+	"city": "city", "state": "state", "zip": "zip", "zip_code": "zip", "postal_code": "zip",
+	"country": "country", "phone": "phone",
+}
+
+// ReadMatchCSV reads match inputs from CSV with a header row naming the
+// columns: name, address, city, state, zip, phone and country, in any order.
+// Other columns are ignored.
+func ReadMatchCSV(r io.Reader) ([]MatchInput, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err != nil {
+		return nil, err
+	}
+	cols := map[string]int{}
+	for i, h := range header {
+		if col, ok := matchColumns[strings.ToLower(strings.TrimSpace(h))]; ok {
+			cols[col] = i
+		}
+	}
+	if _, ok := cols["name"]; !ok {
+		return nil, errors.New("yelp: CSV has no name column")
+	}
+
+	inputs := []MatchInput{}
+	for line := 2; ; line++ {
+		row, err := cr.Read()
+		if err == io.EOF {
+			return inputs, nil
+		}
+		if err != nil {
+			return inputs, fmt.Errorf("yelp: CSV line %d: %w", line, err)
+		}
+		get := func(col string) string {
+			if i, ok := cols[col]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+		inputs = append(inputs, MatchInput{
+			Name:    get("name"),
+			Address: get("address"),
+			City:    get("city"),
+			State:   get("state"),
+			ZipCode: get("zip"),
+			Country: get("country"),
+			Phone:   get("phone"),
+		})
+	}
+}
+
+// WriteMatchCSV writes the results as CSV: the input columns followed by the
+// id, name and URL of the business found, the confidence and the method.
+func WriteMatchCSV(w io.Writer, results []MatchResult) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"name", "address", "city", "state", "zip", "country", "phone",
+		"yelp_id", "yelp_name", "yelp_url", "confidence", "method"})
+	for _, r := range results {
+		in := r.Input
+		cw.Write([]string{in.Name, in.Address, in.City, in.State, in.ZipCode, in.Country, in.Phone,
+			r.Business.ID, r.Business.Name, r.Business.URL, fmt.Sprintf("%.2f", r.Confidence), string(r.Method)})
+	}
+	cw.Flush()
+	return cw.Error()
+}
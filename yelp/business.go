@@ -1,5 +1,7 @@
 package yelp
 
+import "net/url"
+
 // Category describes a business.
 type Category struct {
 	Alias string `json:"alias"`
@@ -9,6 +11,7 @@ type Category struct {
 // Business defines a business returned by the Yelp API.
 type Business struct {
 	ID           string      `json:"id"`
+	Alias        string      `json:"alias"`
 	Name         string      `json:"name"`
 	ImageURL     string      `json:"image_url"`
 	IsClaimed    bool        `json:"is_claimed"`
@@ -24,7 +27,70 @@ type Business struct {
 	Location     Location    `json:"location"`
 	Transactions []string    `json:"transactions"`
 
+	// Only in business details
+	Attributes   Attributes     `json:"attributes"`
+	HealthScore  *HealthScore   `json:"health_score"`
+	Hours        []Hours        `json:"hours"`
+	SpecialHours []SpecialHours `json:"special_hours"`
+
 	// Only in search result
 	DisplayPhone string  `json:"display_phone"`
 	Distance     float64 `json:"distance"`
+
+	// Locale is the locale the business was requested with, if any.
+	Locale string `json:"-"`
+
+	// Stale is true when the business was served from an expired cache
+	// entry because Yelp could not be reached, see WithStaleIfError.
+	Stale bool `json:"-"`
+}
+
+// BusinessOptions contains the available parameters for the Business API.
+type BusinessOptions struct {
+	Locale         *string
+	DevicePlatform DevicePlatform
+}
+
+// URLValues returns BusinessOptions as url.Values.
+func (bo BusinessOptions) URLValues() url.Values {
+	vals := url.Values{}
+	if bo.Locale != nil {
+		vals.Add("locale", *bo.Locale)
+	}
+	if bo.DevicePlatform != "" {
+		vals.Add("device_platform", string(bo.DevicePlatform))
+	}
+	return vals
+}
+
+// PrimaryPhoto returns the URL of the main photo of the business: its first
+// photo, only in business details, or its image. It is "" when the business
+// has none, so templates can fall back to a placeholder instead of a broken
+// image.
+func (b Business) PrimaryPhoto() string {
+	for _, p := range b.Photos {
+		if p != "" {
+			return p
+		}
+	}
+	return b.ImageURL
+}
+
+// HasPhotos returns true when the business has a photo or an image.
+func (b Business) HasPhotos() bool {
+	return b.PrimaryPhoto() != ""
+}
+
+// HasReviews returns true when the business has been reviewed.
+func (b Business) HasReviews() bool {
+	return b.ReviewCount > 0
+}
+
+// RatingOrDefault returns the rating of the business, or def when it has no
+// reviews, as Yelp reports a rating of 0 for businesses without reviews.
+func (b Business) RatingOrDefault(def float64) float64 {
+	if !b.HasReviews() || b.Rating == 0 {
+		return def
+	}
+	return b.Rating
 }
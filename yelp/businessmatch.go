@@ -0,0 +1,64 @@
+package yelp
+
+import "net/url"
+
+// matchPath is the path to match a business by name and address
+const matchPath = "/businesses/matches"
+
+// MatchOptions contains the available parameters for the Business Match API.
+// Name, Address1, City, State and Country are required.
+type MatchOptions struct {
+	Name        *string
+	Address1    *string
+	Address2    *string
+	Address3    *string
+	City        *string
+	State       *string
+	Country     *string
+	ZipCode     *string
+	Phone       *string
+	Coordinates *Coordinates
+	Limit       *int64
+
+	// MatchThreshold is how strict the match is: "none", "default" or
+	// "strict".
+	MatchThreshold *string
+}
+
+// matchResults reflects the JSON returned by the Business Match API.
+type matchResults struct {
+	Businesses []Business `json:"businesses"`
+}
+
+// IsValid returns true when the required parameters are set.
+func (mo MatchOptions) IsValid() bool {
+	return mo.Name != nil && mo.Address1 != nil && mo.City != nil && mo.State != nil && mo.Country != nil
+}
+
+// URLValues returns MatchOptions as url.Values.
+func (mo MatchOptions) URLValues() url.Values {
+	vals := url.Values{}
+	if mo.Coordinates != nil {
+		vals = mo.Coordinates.URLValues()
+	}
+
+	add := func(key string, val *string) {
+		if val != nil {
+			vals.Add(key, *val)
+		}
+	}
+	add("name", mo.Name)
+	add("address1", mo.Address1)
+	add("address2", mo.Address2)
+	add("address3", mo.Address3)
+	add("city", mo.City)
+	add("state", mo.State)
+	add("country", mo.Country)
+	add("zip_code", mo.ZipCode)
+	add("phone", mo.Phone)
+	add("match_threshold", mo.MatchThreshold)
+	if mo.Limit != nil {
+		vals.Add("limit", IntString(*mo.Limit))
+	}
+	return vals
+}
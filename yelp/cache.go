@@ -0,0 +1,240 @@
+package yelp
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Cache stores the response bodies of GET requests by URL.
+type Cache interface {
+	Get(key string) (CacheEntry, bool)
+	Set(key string, entry CacheEntry)
+}
+
+// CacheEntry is a response body and the time it was received.
+type CacheEntry struct {
+	Body     []byte
+	StoredAt time.Time
+}
+
+// DefaultMemoryCacheSize is the number of entries of a MemoryCache made with
+// NewMemoryCache.
+const DefaultMemoryCacheSize = 10000
+
+// MemoryCache is a Cache keeping the entries in memory. Past its size, the
+// least recently used entry is evicted on Set, so a long-running client does
+// not keep every response it ever cached.
+type MemoryCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+// memoryCacheItem is an element of the lru list of a MemoryCache.
+type memoryCacheItem struct {
+	key   string
+	entry CacheEntry
+}
+
+// NewMemoryCache returns an empty MemoryCache holding up to
+// DefaultMemoryCacheSize entries.
+func NewMemoryCache() *MemoryCache {
+	return NewMemoryCacheSize(DefaultMemoryCacheSize)
+}
+
+// NewMemoryCacheSize returns an empty MemoryCache holding up to size entries.
+func NewMemoryCacheSize(size int) *MemoryCache {
+	if size < 1 {
+		size = 1
+	}
+	return &MemoryCache{size: size, entries: map[string]*list.Element{}, lru: list.New()}
+}
+
+// Get returns the entry stored for key.
+func (mc *MemoryCache) Get(key string) (CacheEntry, bool) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	el, ok := mc.entries[key]
+	if !ok {
+		return CacheEntry{}, false
+	}
+	mc.lru.MoveToFront(el)
+	return el.Value.(*memoryCacheItem).entry, true
+}
+
+// Set stores entry for key, evicting the least recently used entry when the
+// cache is full.
+func (mc *MemoryCache) Set(key string, entry CacheEntry) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	if el, ok := mc.entries[key]; ok {
+		el.Value.(*memoryCacheItem).entry = entry
+		mc.lru.MoveToFront(el)
+		return
+	}
+	mc.entries[key] = mc.lru.PushFront(&memoryCacheItem{key: key, entry: entry})
+	if mc.lru.Len() > mc.size {
+		oldest := mc.lru.Back()
+		mc.lru.Remove(oldest)
+		delete(mc.entries, oldest.Value.(*memoryCacheItem).key)
+	}
+}
+
+// WithCache makes the client keep the responses of the given endpoint families
+// in cache and serve them from it for ttl. Only business details are cached
+// when no endpoint family is given.
+func WithCache(cache Cache, ttl time.Duration, endpoints ...Endpoint) Option {
+	return func(c *client) {
+		if len(endpoints) == 0 {
+			endpoints = []Endpoint{EndpointBusiness}
+		}
+		c.cache = cache
+		c.cacheTTL = ttl
+		c.cacheEndpoints = map[Endpoint]bool{}
+		for _, ep := range endpoints {
+			c.cacheEndpoints[ep] = true
+		}
+	}
+}
+
+// WithStaleWhileRevalidate makes the client serve cached responses up to
+// window past their ttl, refreshing them in the background. onChange, if not
+// nil, is called with the URL and both bodies when the refreshed response
+// differs from the stale one.
+func WithStaleWhileRevalidate(window time.Duration, onChange func(key string, stale, fresh []byte)) Option {
+	return func(c *client) {
+		c.staleWindow = window
+		c.onChange = onChange
+	}
+}
+
+// cached returns true when the responses of the request are cached.
+func (c *client) cached(ep Endpoint, method string) bool {
+	return c.cache != nil && !c.dryRun && method == "GET" && c.cacheEndpoints[ep]
+}
+
+// cachedDo serves the GET request from the cache when the entry is fresh or
+// within the stale window, and fetches and stores it otherwise. The response
+// is nil when served from the cache.
+func (c *client) cachedDo(ctx context.Context, ep Endpoint, url string, headers map[string]string, v interface{}) (*http.Response, error) {
+	if entry, ok := c.cache.Get(url); ok {
+		age := c.clock.Now().Sub(entry.StoredAt)
+		if age <= c.cacheTTL {
+			c.log(ctx, slog.LevelDebug, "yelp: cache hit", "endpoint", ep, "age", age)
+			return nil, json.Unmarshal(entry.Body, v)
+		}
+		if !c.serverless && age <= c.cacheTTL+c.staleWindow {
+			c.log(ctx, slog.LevelDebug, "yelp: stale cache hit", "endpoint", ep, "age", age)
+			c.revalidate(ctx, ep, url, headers, entry)
+			return nil, json.Unmarshal(entry.Body, v)
+		}
+	}
+
+	raw := json.RawMessage{}
+	resp, err := c.fetch(ctx, ep, "GET", url, nil, headers, &raw)
+	if err != nil {
+		if entry, ok := c.staleIfError(url, err); ok {
+			c.log(ctx, slog.LevelWarn, "yelp: serving stale cache entry", "endpoint", ep, "age", c.clock.Now().Sub(entry.StoredAt), "error", err)
+			if err := json.Unmarshal(entry.Body, v); err != nil {
+				return nil, err
+			}
+			markStale(v)
+			return nil, nil
+		}
+		return resp, err
+	}
+	c.cache.Set(url, CacheEntry{Body: raw, StoredAt: c.clock.Now()})
+	return resp, json.Unmarshal(raw, v)
+}
+
+// WithStaleIfError makes the client serve expired cache entries up to maxAge
+// old, any age when zero, when Yelp cannot be reached or fails with a 5xx,
+// instead of returning the error. The results are flagged with Stale.
+func WithStaleIfError(maxAge time.Duration) Option {
+	return func(c *client) {
+		c.staleIfErr = true
+		c.staleMaxAge = maxAge
+	}
+}
+
+// staleIfError returns the cache entry of url to serve instead of err.
+func (c *client) staleIfError(url string, err error) (CacheEntry, bool) {
+	if !c.staleIfErr {
+		return CacheEntry{}, false
+	}
+	switch ClassifyError(err) {
+	case ErrorClassServer, ErrorClassNetwork, ErrorClassTimeout:
+	default:
+		return CacheEntry{}, false
+	}
+	entry, ok := c.cache.Get(url)
+	if !ok || (c.staleMaxAge > 0 && c.clock.Now().Sub(entry.StoredAt) > c.staleMaxAge) {
+		return CacheEntry{}, false
+	}
+	return entry, true
+}
+
+// markStale sets the Stale flag of the results v points to.
+func markStale(v interface{}) {
+	switch v := v.(type) {
+	case *SearchResults:
+		v.Stale = true
+	case *Business:
+		v.Stale = true
+	case *ReviewsResults:
+		v.Stale = true
+	case *EventsResults:
+		v.Stale = true
+	}
+}
+
+// revalidate refreshes the stale entry of url in the background, unless it is
+// already being refreshed.
+func (c *client) revalidate(ctx context.Context, ep Endpoint, url string, headers map[string]string, stale CacheEntry) {
+	c.mu.Lock()
+	if c.refreshing[url] {
+		c.mu.Unlock()
+		return
+	}
+	if c.refreshing == nil {
+		c.refreshing = map[string]bool{}
+	}
+	c.refreshing[url] = true
+	c.mu.Unlock()
+
+	finish := func() {
+		c.mu.Lock()
+		delete(c.refreshing, url)
+		c.mu.Unlock()
+	}
+	done, err := c.track()
+	if err != nil {
+		finish()
+		return
+	}
+
+	go func() {
+		defer done()
+		defer finish()
+
+		ctx, cancel := c.withShutdown(context.WithoutCancel(ctx))
+		defer cancel()
+
+		raw := json.RawMessage{}
+		if _, err := c.fetch(ctx, ep, "GET", url, nil, headers, &raw); err != nil {
+			c.log(ctx, slog.LevelWarn, "yelp: revalidating cache entry", "endpoint", ep, "error", err)
+			return
+		}
+		c.cache.Set(url, CacheEntry{Body: raw, StoredAt: c.clock.Now()})
+		if c.onChange != nil && !bytes.Equal(stale.Body, raw) {
+			c.onChange(url, stale.Body, raw)
+		}
+	}()
+}
@@ -0,0 +1,105 @@
+package yelp
+
+import (
+	"context"
+	"time"
+)
+
+// ChangeKind is the kind of change a Refresher detected on a business.
+type ChangeKind string
+
+const (
+	// ChangeNewReview is a review not seen in the previous refresh.
+	ChangeNewReview ChangeKind = "new_review"
+
+	// ChangeRating is a change of the rating of the business.
+	ChangeRating ChangeKind = "rating_changed"
+
+	// ChangeClosed is a business now reported as permanently closed.
+	ChangeClosed ChangeKind = "closed"
+)
+
+// ChangeEvent describes a change detected on a mirrored business.
+type ChangeEvent struct {
+	Kind         ChangeKind `json:"kind"`
+	BusinessID   string     `json:"business_id"`
+	BusinessName string     `json:"business_name"`
+	DetectedAt   time.Time  `json:"detected_at"`
+
+	// Set for ChangeRating.
+	OldRating float64 `json:"old_rating,omitempty"`
+	NewRating float64 `json:"new_rating,omitempty"`
+
+	// Set for ChangeNewReview.
+	Review *Review `json:"review,omitempty"`
+}
+
+// Publisher publishes the changes detected by a Refresher, e.g. to a message
+// broker. See package publish for Kafka and NATS adapters.
+type Publisher interface {
+	Publish(ctx context.Context, e ChangeEvent) error
+}
+
+// PublisherFunc is an adapter to allow the use of ordinary functions as
+// Publisher.
+type PublisherFunc func(ctx context.Context, e ChangeEvent) error
+
+// Publish calls f(ctx, e).
+func (f PublisherFunc) Publish(ctx context.Context, e ChangeEvent) error {
+	return f(ctx, e)
+}
+
+// mirrorState is what a Refresher remembers of a business to detect changes.
+type mirrorState struct {
+	rating float64
+	closed bool
+	// reviews are the ids of the reviews seen, nil when they were never
+	// fetched.
+	reviews map[string]bool
+}
+
+// changes returns the changes from prev to b and its reviews, nil reviews
+// meaning they could not be fetched, and the new state. New reviews are only
+// reported once the reviews of the business were fetched before, so the
+// reviews fetched first are a baseline rather than new.
+func changes(prev *mirrorState, b Business, reviews []Review, now time.Time) ([]ChangeEvent, *mirrorState) {
+	cur := &mirrorState{rating: b.Rating, closed: b.IsClosed}
+	if reviews != nil {
+		cur.reviews = map[string]bool{}
+		for _, r := range reviews {
+			cur.reviews[r.ID] = true
+		}
+	}
+	if prev == nil {
+		return nil, cur
+	}
+
+	event := func(kind ChangeKind) ChangeEvent {
+		return ChangeEvent{Kind: kind, BusinessID: b.ID, BusinessName: b.Name, DetectedAt: now}
+	}
+	events := []ChangeEvent{}
+	if b.IsClosed && !prev.closed {
+		events = append(events, event(ChangeClosed))
+	}
+	if b.Rating != prev.rating {
+		e := event(ChangeRating)
+		e.OldRating, e.NewRating = prev.rating, b.Rating
+		events = append(events, e)
+	}
+	if reviews == nil {
+		// Keep the previous reviews so they are not reported again once they
+		// can be fetched.
+		cur.reviews = prev.reviews
+	}
+	if prev.reviews == nil {
+		return events, cur
+	}
+	for i := range reviews {
+		if !prev.reviews[reviews[i].ID] {
+			e := event(ChangeNewReview)
+			e.Review = &reviews[i]
+			events = append(events, e)
+		}
+	}
+	return events, cur
+}
@@ -0,0 +1,38 @@
+package yelp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// SearchCities makes the search so in each of the cities, e.g. "Austin, TX",
+// several at a time through the rate limiting of the client, and returns
+// the results by city, for comparing markets. Location and Coordinates of so
+// are ignored. A city failing to search does not stop the others; the errors
+// are joined and its results left out.
+func (c *client) SearchCities(ctx context.Context, cities []string, so SearchOptions) (map[string]SearchResults, error) {
+	so.Coordinates = nil
+	results := make([]SearchResults, len(cities))
+	errs := make([]error, len(cities))
+	c.forEach(ctx, progressFromContext(ctx), len(cities), func(i int) error {
+		cso := so
+		cso.Location = StringPtr(cities[i])
+		results[i], errs[i] = c.Search(ctx, cso)
+		if errs[i] != nil {
+			errs[i] = fmt.Errorf("yelp: searching %s: %w", cities[i], errs[i])
+		}
+		return errs[i]
+	})
+
+	byCity := make(map[string]SearchResults, len(cities))
+	for i, city := range cities {
+		if errs[i] == nil && results[i].Businesses != nil {
+			byCity[city] = results[i]
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return byCity, err
+	}
+	return byCity, ctx.Err()
+}
@@ -0,0 +1,153 @@
+package yelp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+)
+
+// Sentinel errors matched by the APIErrors of their class with errors.Is,
+// e.g. errors.Is(err, yelp.ErrNotFound).
+var (
+	ErrRateLimited    = errors.New("yelp: rate limited")
+	ErrDailyLimit     = errors.New("yelp: daily limit of the API key reached")
+	ErrUnauthorized   = errors.New("yelp: unauthorized")
+	ErrNotFound       = errors.New("yelp: not found")
+	ErrInvalidRequest = errors.New("yelp: invalid request")
+	ErrServer         = errors.New("yelp: server error")
+)
+
+// ErrorClass is the class of an error, telling how to react to it.
+type ErrorClass string
+
+// Available error classes.
+const (
+	ErrorClassNone           ErrorClass = ""
+	ErrorClassRateLimited    ErrorClass = "rate_limited"
+	ErrorClassQuota          ErrorClass = "quota"
+	ErrorClassUnauthorized   ErrorClass = "unauthorized"
+	ErrorClassNotFound       ErrorClass = "not_found"
+	ErrorClassInvalidRequest ErrorClass = "invalid_request"
+	ErrorClassServer         ErrorClass = "server"
+	ErrorClassNetwork        ErrorClass = "network"
+	ErrorClassTimeout        ErrorClass = "timeout"
+	ErrorClassCanceled       ErrorClass = "canceled"
+	ErrorClassDecode         ErrorClass = "decode"
+	ErrorClassUnknown        ErrorClass = "unknown"
+)
+
+// Retryable returns true when a request failing with an error of the class
+// may succeed when sent again.
+func (ec ErrorClass) Retryable() bool {
+	switch ec {
+	case ErrorClassRateLimited, ErrorClassServer, ErrorClassNetwork, ErrorClassTimeout:
+		return true
+	}
+	return false
+}
+
+// sentinel returns the sentinel error of the class, if any.
+func (ec ErrorClass) sentinel() error {
+	switch ec {
+	case ErrorClassRateLimited:
+		return ErrRateLimited
+	case ErrorClassQuota:
+		return ErrDailyLimit
+	case ErrorClassUnauthorized:
+		return ErrUnauthorized
+	case ErrorClassNotFound:
+		return ErrNotFound
+	case ErrorClassInvalidRequest:
+		return ErrInvalidRequest
+	case ErrorClassServer:
+		return ErrServer
+	}
+	return nil
+}
+
+// errorCodes maps the error codes returned by Yelp to their class. Codes not
+// listed are classified by the status of the response.
+var errorCodes = map[string]ErrorClass{
+	"TOO_MANY_REQUESTS_PER_SECOND": ErrorClassRateLimited,
+	"ACCESS_LIMIT_REACHED":         ErrorClassQuota,
+	"DAILY_POOL_LIMIT_REACHED":     ErrorClassQuota,
+	"TOKEN_MISSING":                ErrorClassUnauthorized,
+	"TOKEN_INVALID":                ErrorClassUnauthorized,
+	"UNAUTHORIZED_ACCESS_TOKEN":    ErrorClassUnauthorized,
+	"AUTHORIZATION_ERROR":          ErrorClassUnauthorized,
+	"BUSINESS_UNAVAILABLE":         ErrorClassNotFound,
+	"BUSINESS_NOT_FOUND":           ErrorClassNotFound,
+	"NOT_FOUND":                    ErrorClassNotFound,
+	"VALIDATION_ERROR":             ErrorClassInvalidRequest,
+	"FIELD_REQUIRED":               ErrorClassInvalidRequest,
+	"INVALID_LOCALE":               ErrorClassInvalidRequest,
+	"LOCATION_MISSING":             ErrorClassInvalidRequest,
+	"LOCATION_NOT_FOUND":           ErrorClassInvalidRequest,
+	"INTERNAL_ERROR":               ErrorClassServer,
+	"SERVICE_UNAVAILABLE":          ErrorClassServer,
+}
+
+// ClassifyError returns the class of err, ErrorClassNone when it is nil. The
+// class of an APIError follows its Yelp error code, or its status when the
+// code is unknown. The retries of the client are driven by the classes, see
+// ErrorClass.Retryable.
+func ClassifyError(err error) ErrorClass {
+	if err == nil {
+		return ErrorClassNone
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Class()
+	}
+
+	var netErr net.Error
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, ErrQuotaExceeded):
+		return ErrorClassQuota
+	case errors.Is(err, ErrMissingAPIKey), errors.Is(err, ErrInvalidAPIKey):
+		return ErrorClassUnauthorized
+	case errors.Is(err, context.Canceled), errors.Is(err, ErrClientClosed):
+		return ErrorClassCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorClassTimeout
+	case errors.As(err, &netErr):
+		if netErr.Timeout() {
+			return ErrorClassTimeout
+		}
+		return ErrorClassNetwork
+	case errors.As(err, &syntaxErr), errors.As(err, &typeErr):
+		return ErrorClassDecode
+	}
+	return ErrorClassUnknown
+}
+
+// Class returns the class of the error, see ClassifyError.
+func (e *APIError) Class() ErrorClass {
+	if ec, ok := errorCodes[e.Code]; ok {
+		return ec
+	}
+	switch {
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrorClassRateLimited
+	case e.StatusCode == http.StatusUnauthorized, e.StatusCode == http.StatusForbidden:
+		return ErrorClassUnauthorized
+	case e.StatusCode == http.StatusNotFound:
+		return ErrorClassNotFound
+	case e.StatusCode >= 500:
+		return ErrorClassServer
+	case e.StatusCode >= 400:
+		return ErrorClassInvalidRequest
+	}
+	return ErrorClassUnknown
+}
+
+// Is returns true when target is the sentinel error of the class of e.
+func (e *APIError) Is(target error) bool {
+	s := e.Class().sentinel()
+	return s != nil && s == target
+}
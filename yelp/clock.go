@@ -0,0 +1,56 @@
+package yelp
+
+import "time"
+
+// Clock tells the time and waits. The client uses it to back off between
+// retries and to age cached responses, so tests can control time instead of
+// sleeping.
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a timer made by a Clock.
+type Timer interface {
+	// C returns the channel the time is sent on when the timer fires.
+	C() <-chan time.Time
+
+	// Stop prevents the timer from firing, see time.Timer.Stop.
+	Stop() bool
+}
+
+// WithClock makes the client use clk instead of the system clock.
+func WithClock(clk Clock) Option {
+	return func(c *client) {
+		c.clock = clk
+	}
+}
+
+// systemClock is the Clock of the time package.
+type systemClock struct{}
+
+// Now returns time.Now().
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Sleep calls time.Sleep(d).
+func (systemClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// NewTimer returns a time.Timer.
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+// systemTimer is a time.Timer as Timer.
+type systemTimer struct {
+	*time.Timer
+}
+
+// C returns the channel of the timer.
+func (t systemTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
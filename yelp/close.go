@@ -0,0 +1,56 @@
+package yelp
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrClientClosed is returned by the calls made after Close.
+var ErrClientClosed = errors.New("yelp: client closed")
+
+// Close stops the client: new calls fail with ErrClientClosed, background work
+// is stopped and Close waits for the calls in flight, including the ones
+// queued by a Scheduler. When ctx is done first the calls in flight are
+// canceled and ctx's error is returned once they returned.
+func (c *client) Close(ctx context.Context) error {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+
+	idle := make(chan struct{})
+	go func() {
+		c.inflight.Wait()
+		close(idle)
+	}()
+
+	select {
+	case <-idle:
+		c.stop()
+		return nil
+	case <-ctx.Done():
+		c.stop()
+		<-idle
+		return ctx.Err()
+	}
+}
+
+// track registers a call in flight. done must be called when it returns.
+func (c *client) track() (done func(), err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, ErrClientClosed
+	}
+	c.inflight.Add(1)
+	return c.inflight.Done, nil
+}
+
+// withShutdown returns a copy of ctx canceled when the client is stopped.
+func (c *client) withShutdown(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(c.shutdown, cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
@@ -0,0 +1,122 @@
+package yelp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// ClosureStatus is the state of a stored business found by a ClosureChecker.
+type ClosureStatus string
+
+// Available closure statuses.
+const (
+	// ClosureOpen is a business Yelp still lists as operating.
+	ClosureOpen ClosureStatus = "open"
+
+	// ClosureClosed is a business Yelp reports as permanently closed.
+	ClosureClosed ClosureStatus = "closed"
+
+	// ClosureDeleted is a business Yelp no longer knows, answered with a 404.
+	ClosureDeleted ClosureStatus = "deleted"
+
+	// ClosureMerged is a business Yelp answers with another one, as when
+	// duplicate listings are merged.
+	ClosureMerged ClosureStatus = "merged"
+
+	// ClosureUnknown is a business that could not be checked.
+	ClosureUnknown ClosureStatus = "unknown"
+)
+
+// ClosureResult is the outcome of checking a business.
+type ClosureResult struct {
+	ID     string
+	Status ClosureStatus
+
+	// CurrentID is the id of the business Yelp answered with, which differs
+	// from ID for merged businesses.
+	CurrentID string
+
+	// Business is the business Yelp answered with, if any.
+	Business Business
+
+	// Err is the error of the check for ClosureUnknown.
+	Err error
+}
+
+// ClosureChecker verifies that stored businesses are still listed by Yelp,
+// so directories can prune dead listings.
+type ClosureChecker struct {
+	client  Client
+	workers int
+}
+
+// NewClosureChecker returns a ClosureChecker looking up businesses with c,
+// one at a time.
+func NewClosureChecker(c Client) *ClosureChecker {
+	return &ClosureChecker{client: c, workers: 1}
+}
+
+// SetWorkers sets the number of businesses looked up at the same time.
+func (cc *ClosureChecker) SetWorkers(n int) {
+	cc.workers = n
+}
+
+// Check looks up every business of ids and returns their status, in the
+// order of ids, reporting progress as set with WithProgress.
+func (cc *ClosureChecker) Check(ctx context.Context, ids []string) []ClosureResult {
+	results := make([]ClosureResult, len(ids))
+	runBatch(ctx, progressFromContext(ctx), cc.workers, len(ids), func(i int) error {
+		results[i] = cc.check(ctx, ids[i])
+		return results[i].Err
+	})
+	for i, r := range results {
+		if r.Status == "" {
+			results[i] = ClosureResult{ID: ids[i], Status: ClosureUnknown, Err: ctx.Err()}
+		}
+	}
+	return results
+}
+
+// Run checks the businesses returned by ids every interval until ctx is done
+// and calls report with the results. ids is called before each check, so it
+// can read them from a store.
+func (cc *ClosureChecker) Run(ctx context.Context, interval time.Duration, ids func() []string, report func([]ClosureResult)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		report(cc.Check(ctx, ids()))
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// check returns the status of the business with id.
+func (cc *ClosureChecker) check(ctx context.Context, id string) ClosureResult {
+	r := ClosureResult{ID: id}
+	b, err := cc.client.BusinessByID(ctx, id, BusinessOptions{})
+	var apiErr *APIError
+	switch {
+	case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound:
+		r.Status = ClosureDeleted
+		return r
+	case err != nil:
+		r.Status, r.Err = ClosureUnknown, err
+		return r
+	}
+
+	r.Business, r.CurrentID = b, b.ID
+	switch {
+	case b.ID != id && b.Alias != id:
+		r.Status = ClosureMerged
+	case b.IsClosed:
+		r.Status = ClosureClosed
+	default:
+		r.Status = ClosureOpen
+	}
+	return r
+}
@@ -0,0 +1,52 @@
+package yelp
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+// maxSearchLimit is the largest number of businesses returned by one search.
+const maxSearchLimit = 50
+
+// ErrNoCategory is returned by Competitors when the business has no category
+// to look for competitors in.
+var ErrNoCategory = errors.New("yelp: business has no category")
+
+// Competitors returns the businesses within radiusMeters of the business that
+// share its first category, ordered by rating and then review count.
+func (c *client) Competitors(ctx context.Context, businessID string, radiusMeters int) ([]Business, error) {
+	b, err := c.BusinessByID(ctx, businessID, BusinessOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if len(b.Categories) == 0 {
+		return nil, ErrNoCategory
+	}
+
+	coords := b.Coodinates
+	sr, err := c.Search(ctx, SearchOptions{
+		Coordinates: &coords,
+		Radius:      Int64Ptr(int64(radiusMeters)),
+		Categories:  StringPtr(b.Categories[0].Alias),
+		Limit:       Int64Ptr(maxSearchLimit),
+		SortBy:      StringPtr("rating"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	competitors := []Business{}
+	for _, sb := range sr.Businesses {
+		if sb.ID != b.ID && sb.Alias != businessID {
+			competitors = append(competitors, sb)
+		}
+	}
+	sort.SliceStable(competitors, func(i, j int) bool {
+		if competitors[i].Rating != competitors[j].Rating {
+			return competitors[i].Rating > competitors[j].Rating
+		}
+		return competitors[i].ReviewCount > competitors[j].ReviewCount
+	})
+	return competitors, nil
+}
@@ -0,0 +1,31 @@
+package yelp
+
+import "context"
+
+// WithMaxConcurrency caps the number of requests the client has in flight to
+// n, so a runaway fan-out of calls cannot open thousands of connections to
+// Yelp. Requests past the cap wait for a free slot, or for their context to
+// be done. Unlike a Scheduler, the cap is not shared between clients and does
+// not take turns between tenants.
+func WithMaxConcurrency(n int) Option {
+	return func(c *client) {
+		if n < 1 {
+			n = 1
+		}
+		c.slots = make(chan struct{}, n)
+	}
+}
+
+// acquireSlot waits for a free slot to send a request. release must be
+// called once the request is done.
+func (c *client) acquireSlot(ctx context.Context) (release func(), err error) {
+	if c.slots == nil {
+		return func() {}, nil
+	}
+	select {
+	case c.slots <- struct{}{}:
+		return func() { <-c.slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,316 @@
+package yelp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Environment variables read by ConfigFromEnv.
+const (
+	EnvAPIKey         = "YELP_API_KEY"
+	EnvAPIHost        = "YELP_API_HOST"
+	EnvAPIVersion     = "YELP_API_VERSION"
+	EnvTimeout        = "YELP_TIMEOUT"
+	EnvLocale         = "YELP_LOCALE"
+	EnvLocaleFallback = "YELP_LOCALE_FALLBACK"
+	EnvMaxRetries     = "YELP_MAX_RETRIES"
+	EnvConcurrency    = "YELP_CONCURRENCY"
+	EnvDryRun         = "YELP_DRY_RUN"
+	EnvMinDelay       = "YELP_MIN_DELAY"
+	EnvMaxBurst       = "YELP_MAX_BURST"
+	EnvNotFoundTTL    = "YELP_NOT_FOUND_TTL"
+	EnvCacheDir       = "YELP_CACHE_DIR"
+	EnvCacheTTL       = "YELP_CACHE_TTL"
+)
+
+// Config holds the configuration of a client. Zero fields keep the defaults.
+type Config struct {
+	APIKey         string
+	APIHost        string
+	APIVersion     string
+	Timeout        time.Duration
+	Locale         string
+	LocaleFallback []string
+	SearchRadius   int64
+	MaxRetries     int
+	RetryBackoff   time.Duration
+	Concurrency    int
+	DryRun         bool
+
+	// MinDelay and MaxBurst enable WithPoliteness when MinDelay is set.
+	MinDelay time.Duration
+	MaxBurst int
+
+	// NotFoundTTL enables WithNotFoundCache when set.
+	NotFoundTTL time.Duration
+
+	// CacheDir enables WithCache with a DirCache in the directory, keeping
+	// business details for CacheTTL, DefaultCacheTTL when zero.
+	CacheDir string
+	CacheTTL time.Duration
+}
+
+// configFile is the layout of configuration files.
+type configFile struct {
+	APIKey         string   `json:"api_key"`
+	APIHost        string   `json:"api_host"`
+	APIVersion     string   `json:"api_version"`
+	Timeout        string   `json:"timeout"`
+	Locale         string   `json:"locale"`
+	LocaleFallback []string `json:"locale_fallback"`
+	SearchRadius   int64    `json:"search_radius"`
+	Concurrency    int      `json:"concurrency"`
+	DryRun         bool     `json:"dry_run"`
+	NotFoundTTL    string   `json:"not_found_ttl"`
+	Retry          struct {
+		MaxRetries int    `json:"max_retries"`
+		Backoff    string `json:"backoff"`
+	} `json:"retry"`
+	Politeness struct {
+		MinDelay string `json:"min_delay"`
+		MaxBurst int    `json:"max_burst"`
+	} `json:"politeness"`
+	Cache struct {
+		Dir string `json:"dir"`
+		TTL string `json:"ttl"`
+	} `json:"cache"`
+}
+
+// DefaultConfigPath returns the path of the configuration file used when none
+// is given, ~/.config/go-yelp/config.yaml on Linux.
+func DefaultConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "go-yelp", "config.yaml"), nil
+}
+
+// LoadConfig reads the Config from the JSON or YAML file at path. Files with a
+// .json extension are read as JSON, any other as YAML, e.g.
+//
+//	api_key: XXXX
+//	locale: de_DE
+//	locale_fallback: [en_US]
+//	search_radius: 1500
+//	retry:
+//	  max_retries: 3
+//	  backoff: 500ms
+func LoadConfig(path string) (Config, error) {
+	cfg := Config{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		fc := configFile{}
+		if err := json.Unmarshal(data, &fc); err != nil {
+			return cfg, fmt.Errorf("yelp: invalid config file %s: %v", path, err)
+		}
+		return fc.config()
+	}
+
+	m, err := parseYAML(data)
+	if err != nil {
+		return cfg, fmt.Errorf("yelp: invalid config file %s: %v", path, err)
+	}
+	if data, err = json.Marshal(m); err != nil {
+		return cfg, err
+	}
+	fc := configFile{}
+	if err := json.Unmarshal(data, &fc); err != nil {
+		return cfg, fmt.Errorf("yelp: invalid config file %s: %v", path, err)
+	}
+	return fc.config()
+}
+
+// config returns the Config described by the file.
+func (fc configFile) config() (Config, error) {
+	cfg := Config{
+		APIKey:         fc.APIKey,
+		APIHost:        fc.APIHost,
+		APIVersion:     fc.APIVersion,
+		Locale:         fc.Locale,
+		LocaleFallback: fc.LocaleFallback,
+		SearchRadius:   fc.SearchRadius,
+		MaxRetries:     fc.Retry.MaxRetries,
+		Concurrency:    fc.Concurrency,
+		DryRun:         fc.DryRun,
+		MaxBurst:       fc.Politeness.MaxBurst,
+		CacheDir:       fc.Cache.Dir,
+	}
+
+	var err error
+	if fc.Timeout != "" {
+		if cfg.Timeout, err = time.ParseDuration(fc.Timeout); err != nil {
+			return cfg, fmt.Errorf("yelp: invalid timeout: %v", err)
+		}
+	}
+	if fc.Retry.Backoff != "" {
+		if cfg.RetryBackoff, err = time.ParseDuration(fc.Retry.Backoff); err != nil {
+			return cfg, fmt.Errorf("yelp: invalid retry backoff: %v", err)
+		}
+	}
+	if fc.Cache.TTL != "" {
+		if cfg.CacheTTL, err = time.ParseDuration(fc.Cache.TTL); err != nil {
+			return cfg, fmt.Errorf("yelp: invalid cache ttl: %v", err)
+		}
+	}
+	if fc.NotFoundTTL != "" {
+		if cfg.NotFoundTTL, err = time.ParseDuration(fc.NotFoundTTL); err != nil {
+			return cfg, fmt.Errorf("yelp: invalid not found ttl: %v", err)
+		}
+	}
+	if fc.Politeness.MinDelay != "" {
+		if cfg.MinDelay, err = time.ParseDuration(fc.Politeness.MinDelay); err != nil {
+			return cfg, fmt.Errorf("yelp: invalid politeness min delay: %v", err)
+		}
+	}
+	return cfg, nil
+}
+
+// ConfigFromEnv returns the Config set by the YELP_* environment variables.
+// YELP_TIMEOUT is a duration such as "10s" and YELP_LOCALE_FALLBACK a comma
+// separated list of locales.
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{
+		APIKey:     os.Getenv(EnvAPIKey),
+		APIHost:    os.Getenv(EnvAPIHost),
+		APIVersion: os.Getenv(EnvAPIVersion),
+		Locale:     os.Getenv(EnvLocale),
+		CacheDir:   os.Getenv(EnvCacheDir),
+	}
+
+	var err error
+	if v := os.Getenv(EnvTimeout); v != "" {
+		if cfg.Timeout, err = time.ParseDuration(v); err != nil {
+			return cfg, fmt.Errorf("yelp: invalid %s: %v", EnvTimeout, err)
+		}
+	}
+	if v := os.Getenv(EnvLocaleFallback); v != "" {
+		cfg.LocaleFallback = strings.Split(v, ",")
+	}
+	if v := os.Getenv(EnvMaxRetries); v != "" {
+		if cfg.MaxRetries, err = strconv.Atoi(v); err != nil {
+			return cfg, fmt.Errorf("yelp: invalid %s: %v", EnvMaxRetries, err)
+		}
+	}
+	if v := os.Getenv(EnvConcurrency); v != "" {
+		if cfg.Concurrency, err = strconv.Atoi(v); err != nil {
+			return cfg, fmt.Errorf("yelp: invalid %s: %v", EnvConcurrency, err)
+		}
+	}
+	if v := os.Getenv(EnvDryRun); v != "" {
+		if cfg.DryRun, err = strconv.ParseBool(v); err != nil {
+			return cfg, fmt.Errorf("yelp: invalid %s: %v", EnvDryRun, err)
+		}
+	}
+	if v := os.Getenv(EnvCacheTTL); v != "" {
+		if cfg.CacheTTL, err = time.ParseDuration(v); err != nil {
+			return cfg, fmt.Errorf("yelp: invalid %s: %v", EnvCacheTTL, err)
+		}
+	}
+	if v := os.Getenv(EnvNotFoundTTL); v != "" {
+		if cfg.NotFoundTTL, err = time.ParseDuration(v); err != nil {
+			return cfg, fmt.Errorf("yelp: invalid %s: %v", EnvNotFoundTTL, err)
+		}
+	}
+	if v := os.Getenv(EnvMinDelay); v != "" {
+		if cfg.MinDelay, err = time.ParseDuration(v); err != nil {
+			return cfg, fmt.Errorf("yelp: invalid %s: %v", EnvMinDelay, err)
+		}
+	}
+	if v := os.Getenv(EnvMaxBurst); v != "" {
+		if cfg.MaxBurst, err = strconv.Atoi(v); err != nil {
+			return cfg, fmt.Errorf("yelp: invalid %s: %v", EnvMaxBurst, err)
+		}
+	}
+	return cfg, nil
+}
+
+// Options returns the Options matching the Config.
+func (cfg Config) Options() []Option {
+	opts := []Option{}
+	if cfg.APIHost != "" {
+		opts = append(opts, WithAPIHost(cfg.APIHost))
+	}
+	if cfg.APIVersion != "" {
+		opts = append(opts, WithAPIVersion(cfg.APIVersion, nil))
+	}
+	if cfg.Locale != "" {
+		opts = append(opts, WithLocale(cfg.Locale))
+	}
+	if len(cfg.LocaleFallback) > 0 {
+		opts = append(opts, WithLocaleFallback(cfg.LocaleFallback...))
+	}
+	if cfg.SearchRadius > 0 {
+		opts = append(opts, WithSearchRadius(cfg.SearchRadius))
+	}
+	if cfg.MaxRetries > 0 {
+		opts = append(opts, WithPolicy(Policy{MaxRetries: cfg.MaxRetries, Backoff: cfg.RetryBackoff}))
+	}
+	if cfg.Concurrency > 0 {
+		opts = append(opts, WithScheduler(NewScheduler(cfg.Concurrency)))
+	}
+	if cfg.DryRun {
+		opts = append(opts, WithDryRun())
+	}
+	if cfg.MinDelay > 0 {
+		opts = append(opts, WithPoliteness(cfg.MinDelay, cfg.MaxBurst))
+	}
+	if cfg.NotFoundTTL > 0 {
+		opts = append(opts, WithNotFoundCache(cfg.NotFoundTTL))
+	}
+	if cfg.CacheDir != "" {
+		opts = append(opts, withDirCache(cfg.CacheDir, cfg.CacheTTL))
+	}
+	return opts
+}
+
+// DefaultCacheTTL is how long business details are kept in the cache of a
+// Config without CacheTTL.
+const DefaultCacheTTL = 24 * time.Hour
+
+// withDirCache makes the client keep business details in a DirCache in dir
+// for ttl, DefaultCacheTTL when zero.
+func withDirCache(dir string, ttl time.Duration) Option {
+	return func(c *client) {
+		dc, err := NewDirCache(dir)
+		if err != nil {
+			c.setErr(err)
+			return
+		}
+		if ttl <= 0 {
+			ttl = DefaultCacheTTL
+		}
+		WithCache(dc, ttl)(c)
+	}
+}
+
+// NewFromConfig returns a new Yelp client configured by cfg. opts are applied
+// after the ones of cfg.
+func NewFromConfig(cfg Config, opts ...Option) (Client, error) {
+	var c *http.Client
+	if cfg.Timeout > 0 {
+		c = &http.Client{Timeout: cfg.Timeout}
+	}
+	return New(c, cfg.APIKey, append(cfg.Options(), opts...)...)
+}
+
+// NewFromEnv returns a new Yelp client configured by the YELP_* environment
+// variables, see ConfigFromEnv. opts are applied after the ones of the
+// environment.
+func NewFromEnv(opts ...Option) (Client, error) {
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return NewFromConfig(cfg, opts...)
+}
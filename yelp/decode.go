@@ -0,0 +1,52 @@
+package yelp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// errNotClient is returned by the generic helpers given a Client not made by
+// New.
+var errNotClient = errors.New("yelp: client must be made by New")
+
+// SearchAs makes a search with c and decodes the response into a T, such as a
+// struct with only the fields needed, which is faster than decoding whole
+// SearchResults on large crawls. T must reflect the JSON of the Search API.
+// Locale fallbacks are not applied, as the results cannot be inspected.
+func SearchAs[T any](ctx context.Context, c Client, so SearchOptions) (T, error) {
+	var v T
+	cl, ok := c.(*client)
+	if !ok {
+		return v, errNotClient
+	}
+
+	so, err := cl.prepareSearch(ctx, so)
+	if err != nil {
+		return v, err
+	}
+	so.Locale = cl.localeOr(so.Locale)
+	urlStr := cl.url(searchPath) + "?" + so.URLValues().Encode()
+	_, err = cl.authedDo(ctx, EndpointSearch, "GET", urlStr, nil, cl.localeHeaders(ctx, so.Locale), &v)
+	return v, err
+}
+
+// BusinessByIDAs looks for a business by its id with c and decodes the
+// response into a T, see SearchAs. T must reflect the JSON of the Business
+// API.
+func BusinessByIDAs[T any](ctx context.Context, c Client, businessID string, bo BusinessOptions) (T, error) {
+	var v T
+	cl, ok := c.(*client)
+	if !ok {
+		return v, errNotClient
+	}
+
+	bo.Locale = cl.localeOr(bo.Locale)
+	urlStr := cl.url(fmt.Sprintf(businessPath, url.PathEscape(businessID)))
+	if vals := bo.URLValues(); len(vals) > 0 {
+		urlStr += "?" + vals.Encode()
+	}
+	_, err := cl.authedDo(ctx, EndpointBusiness, "GET", urlStr, nil, cl.localeHeaders(ctx, bo.Locale), &v)
+	return v, err
+}
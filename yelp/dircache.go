@@ -0,0 +1,111 @@
+package yelp
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DirCache is a Cache keeping each entry in a file of a directory, so the
+// entries outlive the process and can be shared between processes, e.g.
+// filled overnight by "yelp warm" and read by a service during the day.
+type DirCache struct {
+	dir string
+}
+
+// NewDirCache returns a DirCache keeping its entries in dir, which is created
+// when missing.
+func NewDirCache(dir string) (*DirCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &DirCache{dir: dir}, nil
+}
+
+// Get returns the entry stored for key. Unreadable entries are misses.
+func (dc *DirCache) Get(key string) (CacheEntry, bool) {
+	data, err := os.ReadFile(dc.path(key))
+	if err != nil {
+		return CacheEntry{}, false
+	}
+	entry := CacheEntry{}
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return CacheEntry{}, false
+	}
+	return entry, true
+}
+
+// Set stores entry for key, replacing the file atomically. Entries that
+// cannot be written are dropped, as the Cache interface has no errors.
+func (dc *DirCache) Set(key string, entry CacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(dc.dir, ".entry-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), dc.path(key))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// Prune removes the entries stored before the time, such as now minus the
+// ttl and stale window of the cache, as the files are never removed
+// otherwise, and the temporary files left by interrupted writes. Unreadable
+// entries are removed too. It returns the number of files removed.
+func (dc *DirCache) Prune(before time.Time) (int, error) {
+	files, err := os.ReadDir(dc.dir)
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, f := range files {
+		name := f.Name()
+		path := filepath.Join(dc.dir, name)
+		switch {
+		case f.IsDir():
+			continue
+		case strings.HasPrefix(name, ".entry-"):
+			info, err := f.Info()
+			if err != nil || !info.ModTime().Before(before) {
+				continue
+			}
+		case strings.HasSuffix(name, ".json"):
+			data, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			entry := CacheEntry{}
+			if json.Unmarshal(data, &entry) == nil && !entry.StoredAt.Before(before) {
+				continue
+			}
+		default:
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// path returns the file of the entry of key, named by its hash as keys are
+// URLs.
+func (dc *DirCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(dc.dir, hex.EncodeToString(sum[:])+".json")
+}
@@ -0,0 +1,119 @@
+package yelp
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// metersPerMile and feetPerMeter convert distances to imperial units.
+const (
+	metersPerMile = 1609.344
+	feetPerMeter  = 3.28084
+)
+
+// imperialCountries are the countries measuring distances in miles.
+var imperialCountries = map[string]bool{"US": true, "GB": true, "LR": true, "MM": true}
+
+// currencySymbols are the symbols Yelp repeats for the price level of the
+// businesses of a country, "$" for the ones not listed.
+var currencySymbols = map[string]string{
+	"JP": "¥", "GB": "£",
+	"AT": "€", "BE": "€", "DE": "€", "ES": "€", "FI": "€", "FR": "€",
+	"IE": "€", "IT": "€", "NL": "€", "PT": "€",
+}
+
+// decimalCommaLanguages are the languages writing 1,5 for one and a half.
+var decimalCommaLanguages = map[string]bool{
+	"cs": true, "da": true, "de": true, "es": true, "fi": true, "fr": true, "it": true,
+	"nb": true, "nl": true, "pl": true, "pt": true, "sv": true, "tr": true,
+}
+
+// thousandsSeparators are the group separators of the languages not using a
+// comma.
+var thousandsSeparators = map[string]string{
+	"da": ".", "de": ".", "es": ".", "it": ".", "nl": ".", "pt": ".", "tr": ".",
+	"cs": " ", "fi": " ", "fr": " ", "nb": " ", "pl": " ", "sv": " ",
+}
+
+// FormatDistance returns meters formatted for the locale, e.g. "0.3 mi" for
+// "en_US" and "450 m" or "1,2 km" for "de_DE". Distances are in miles, or in
+// feet under a tenth of a mile, in the countries using them.
+func FormatDistance(meters float64, locale string) string {
+	lang := localeLanguage(locale)
+	if imperialCountries[localeCountry(locale)] {
+		miles := meters / metersPerMile
+		if miles < 0.1 {
+			return FormatCount(int64(math.Round(meters*feetPerMeter)), locale) + " ft"
+		}
+		return formatDecimal(miles, lang) + " mi"
+	}
+	if meters < 1000 {
+		return FormatCount(int64(math.Round(meters)), locale) + " m"
+	}
+	return formatDecimal(meters/1000, lang) + " km"
+}
+
+// FormatCount returns n with the thousands separator of the locale, e.g.
+// "1,234" for "en_US" and "1.234" for "de_DE".
+func FormatCount(n int64, locale string) string {
+	sep, ok := thousandsSeparators[localeLanguage(locale)]
+	if !ok {
+		sep = ","
+	}
+	digits := strconv.FormatInt(n, 10)
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	var sb strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			sb.WriteString(sep)
+		}
+		sb.WriteRune(d)
+	}
+	return sign + sb.String()
+}
+
+// FormatPriceLevel returns the price level, 1 to 4, as the currency symbol
+// of the country of the locale repeated, e.g. "$$" for "en_US" and "€€" for
+// "fr_FR". It returns an empty string for levels out of range.
+func FormatPriceLevel(level int, locale string) string {
+	if level < 1 || level > 4 {
+		return ""
+	}
+	symbol, ok := currencySymbols[localeCountry(locale)]
+	if !ok {
+		symbol = "$"
+	}
+	return strings.Repeat(symbol, level)
+}
+
+// DistanceFormatted returns the distance of the business formatted for the
+// locale, see FormatDistance.
+func (b Business) DistanceFormatted(locale string) string {
+	return FormatDistance(b.Distance, locale)
+}
+
+// PriceFormatted returns the price of the business with the currency symbol
+// of the locale, see FormatPriceLevel.
+func (b Business) PriceFormatted(locale string) string {
+	return FormatPriceLevel(b.PriceLevel().Level, locale)
+}
+
+// ReviewCountFormatted returns the review count of the business formatted
+// for the locale, see FormatCount.
+func (b Business) ReviewCountFormatted(locale string) string {
+	return FormatCount(b.ReviewCount, locale)
+}
+
+// formatDecimal returns f with one decimal and the decimal separator of the
+// language.
+func formatDecimal(f float64, lang string) string {
+	s := strconv.FormatFloat(f, 'f', 1, 64)
+	if decimalCommaLanguages[lang] {
+		s = strings.Replace(s, ".", ",", 1)
+	}
+	return s
+}
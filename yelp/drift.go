@@ -0,0 +1,191 @@
+package yelp
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// SchemaDrift lists the differences between the JSON of a response and the
+// type it was decoded into. Paths are dotted, with [] for array elements,
+// e.g. "businesses[].location.address4".
+type SchemaDrift struct {
+	Endpoint Endpoint
+	URL      string
+
+	// Unknown are the keys of the response with no matching field.
+	Unknown []string
+	// Missing are the fields with no matching key in the response that were
+	// in an earlier sampled response of the endpoint, so the fields only
+	// returned by other endpoints sharing the type, such as the details of a
+	// Business, are not reported. Fields Yelp omits when empty are reported
+	// too.
+	Missing []string
+}
+
+// DriftFunc is called with the drifts found in sampled responses.
+type DriftFunc func(SchemaDrift)
+
+// WithSchemaDriftDetector makes the client compare the keys of one response
+// out of every with the fields of the type it is decoded into, and call fn
+// when they differ, as an early warning of changes of the API. every is 1 to
+// check every response. Responses of cached endpoint families are decoded
+// into raw JSON first and are not checked, see WithCache.
+func WithSchemaDriftDetector(every int, fn DriftFunc) Option {
+	return func(c *client) {
+		if every < 1 {
+			every = 1
+		}
+		c.drift = &driftDetector{every: uint64(every), report: fn}
+	}
+}
+
+// driftDetector samples the responses to check.
+type driftDetector struct {
+	every  uint64
+	n      atomic.Uint64
+	report DriftFunc
+
+	mu   sync.Mutex
+	seen map[Endpoint]map[string]bool
+}
+
+// sample returns true when the next response is checked.
+func (d *driftDetector) sample() bool {
+	return d != nil && (d.n.Add(1)-1)%d.every == 0
+}
+
+// check reports the drift between body and the type of v.
+func (d *driftDetector) check(ep Endpoint, url string, body []byte, v interface{}) {
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return
+	}
+	unknown, present, missing := map[string]bool{}, map[string]bool{}, map[string]bool{}
+	compareSchema(reflect.TypeOf(v), doc, "", unknown, present, missing)
+
+	d.mu.Lock()
+	if d.seen == nil {
+		d.seen = map[Endpoint]map[string]bool{}
+	}
+	seen := d.seen[ep]
+	if seen == nil {
+		seen = map[string]bool{}
+		d.seen[ep] = seen
+	}
+	for path := range missing {
+		if !seen[path] {
+			delete(missing, path)
+		}
+	}
+	for path := range present {
+		seen[path] = true
+	}
+	d.mu.Unlock()
+
+	if len(unknown) == 0 && len(missing) == 0 {
+		return
+	}
+	d.report(SchemaDrift{Endpoint: ep, URL: url, Unknown: sortedKeys(unknown), Missing: sortedKeys(missing)})
+}
+
+var (
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	rawMessageType  = reflect.TypeOf(json.RawMessage{})
+)
+
+// compareSchema adds the keys of doc at path not matching a field of t to
+// unknown, the ones matching to present and the fields of t not in doc to
+// missing.
+func compareSchema(t reflect.Type, doc interface{}, path string, unknown, present, missing map[string]bool) {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t == rawMessageType || reflect.PointerTo(t).Implements(unmarshalerType) {
+		return
+	}
+
+	switch doc := doc.(type) {
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Map:
+			for _, val := range doc {
+				compareSchema(t.Elem(), val, path+"{}", unknown, present, missing)
+			}
+		case reflect.Struct:
+			fields := jsonFields(t)
+			for key, val := range doc {
+				ft, ok := fields[key]
+				if !ok {
+					unknown[joinPath(path, key)] = true
+					continue
+				}
+				present[joinPath(path, key)] = true
+				compareSchema(ft, val, joinPath(path, key), unknown, present, missing)
+			}
+			for key := range fields {
+				if _, ok := doc[key]; !ok {
+					missing[joinPath(path, key)] = true
+				}
+			}
+		}
+	case []interface{}:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for _, val := range doc {
+				compareSchema(t.Elem(), val, path+"[]", unknown, present, missing)
+			}
+		}
+	}
+}
+
+// jsonFields returns the types of the fields of the struct t by JSON key,
+// following embedded structs.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" || (!f.IsExported() && !f.Anonymous) {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for key, t := range jsonFields(ft) {
+					fields[key] = t
+				}
+				continue
+			}
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// joinPath returns the path of key in path.
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
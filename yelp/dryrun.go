@@ -0,0 +1,38 @@
+package yelp
+
+import (
+	"context"
+	"net/http"
+)
+
+// DryRunError is returned by every call of a client in dry-run mode once the
+// options are validated and the request is constructed. The request is a copy
+// of the one that would have been sent, with the API key of its
+// Authorization header redacted, so it can be logged or dumped safely.
+type DryRunError struct {
+	Request *http.Request
+}
+
+// newDryRunError returns the DryRunError of req.
+func newDryRunError(req *http.Request) *DryRunError {
+	// The context of the call ends once it returns.
+	req = req.Clone(context.Background())
+	if req.Header.Get("Authorization") != "" {
+		req.Header.Set("Authorization", "Bearer <redacted>")
+	}
+	return &DryRunError{Request: req}
+}
+
+// Error returns a description of the request that would have been sent.
+func (e *DryRunError) Error() string {
+	return "yelp: dry run of " + e.Request.Method + " " + e.Request.URL.String()
+}
+
+// WithDryRun makes the client validate the options and construct the requests
+// without sending them to Yelp. Calls return a *DryRunError holding the
+// request instead, or the validation error when the options are not valid.
+func WithDryRun() Option {
+	return func(c *client) {
+		c.dryRun = true
+	}
+}
@@ -0,0 +1,35 @@
+package yelp_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httputil"
+	"strings"
+	"testing"
+
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+func TestDryRunRedactsAPIKey(t *testing.T) {
+	const key = "secret-api-key"
+	c, err := yelp.New(nil, key, yelp.WithDryRun())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.BusinessByID(context.Background(), "gary-danko-san-francisco", yelp.BusinessOptions{})
+
+	var dryRun *yelp.DryRunError
+	if !errors.As(err, &dryRun) {
+		t.Fatalf("got %v, want a *DryRunError", err)
+	}
+	if got := dryRun.Request.Header.Get("Authorization"); got != "Bearer <redacted>" {
+		t.Errorf("Authorization %q, want it redacted", got)
+	}
+	dump, derr := httputil.DumpRequestOut(dryRun.Request, true)
+	if derr != nil {
+		t.Fatal(derr)
+	}
+	if strings.Contains(string(dump), key) || strings.Contains(err.Error(), key) {
+		t.Errorf("dry run leaks the API key:\n%s", dump)
+	}
+}
@@ -0,0 +1,77 @@
+package yelp
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// requestIDHeaders are the response headers that may hold an id of the
+// request, by preference.
+var requestIDHeaders = []string{"X-Request-Id", "X-Yelp-Request-Id", "X-Amzn-Requestid", "X-Amz-Cf-Id"}
+
+// APIError is returned when Yelp responds with a status other than 200.
+type APIError struct {
+	StatusCode  int
+	Status      string
+	Code        string
+	Description string
+
+	// RequestID is the id of the request reported by Yelp, if any, to quote
+	// in support tickets.
+	RequestID string
+}
+
+// apiErrorBody reflects the JSON Yelp returns along with an error status.
+type apiErrorBody struct {
+	Error struct {
+		Code        string `json:"code"`
+		Description string `json:"description"`
+	} `json:"error"`
+}
+
+// Error returns the status of the response and the error reported by Yelp,
+// with bearer tokens redacted.
+func (e *APIError) Error() string {
+	msg := "Yelp request failed with status " + e.Status
+	if e.Code != "" {
+		msg += ": " + e.Code
+	}
+	if e.Description != "" {
+		msg += ": " + redact(e.Description)
+	}
+	if e.RequestID != "" {
+		msg += " (request id " + e.RequestID + ")"
+	}
+	return msg
+}
+
+// newAPIError returns the APIError of resp, which body is body.
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		RequestID:  requestID(resp.Header),
+	}
+	if e.Status == "" {
+		e.Status = fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+
+	eb := apiErrorBody{}
+	if err := json.Unmarshal(body, &eb); err == nil {
+		e.Code = eb.Error.Code
+		e.Description = eb.Error.Description
+	}
+	return e
+}
+
+// requestID returns the id of the request found in the headers of its
+// response, or an empty string.
+func requestID(h http.Header) string {
+	for _, key := range requestIDHeaders {
+		if id := h.Get(key); id != "" {
+			return id
+		}
+	}
+	return ""
+}
@@ -0,0 +1,128 @@
+package yelp
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Limits of the Events API.
+const (
+	maxEventsLimit  = 50
+	maxEventsWindow = 1000
+	maxEventsRadius = 40000
+)
+
+// Event defines an event returned by the Events API.
+type Event struct {
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	Category        string   `json:"category"`
+	AttendingCount  int64    `json:"attending_count"`
+	InterestedCount int64    `json:"interested_count"`
+	Cost            *float64 `json:"cost"`
+	CostMax         *float64 `json:"cost_max"`
+	EventSiteURL    string   `json:"event_site_url"`
+	ImageURL        string   `json:"image_url"`
+	TicketsURL      string   `json:"tickets_url"`
+	IsCanceled      bool     `json:"is_canceled"`
+	IsFree          bool     `json:"is_free"`
+	IsOfficial      bool     `json:"is_official"`
+	Latitude        float64  `json:"latitude"`
+	Longitude       float64  `json:"longitude"`
+	TimeStart       string   `json:"time_start"`
+	TimeEnd         *string  `json:"time_end"`
+	Location        Location `json:"location"`
+	BusinessID      string   `json:"business_id"`
+}
+
+// EventsOptions contains the available parameters for the Events API.
+type EventsOptions struct {
+	Location    *string
+	Coordinates *Coordinates
+	Radius      *int64
+	Locale      *string
+	Limit       *int64
+	Offset      *int64
+	SortBy      *string
+	SortOn      *string
+	Categories  []string
+	IsFree      *bool
+
+	// StartsAfter returns only events that start at or after the time.
+	StartsAfter time.Time
+	// EndsBefore returns only events that end at or before the time.
+	EndsBefore time.Time
+}
+
+// EventsResults reflects the JSON returned by the Events API.
+type EventsResults struct {
+	Total  int64   `json:"total"`
+	Events []Event `json:"events"`
+
+	// Stale is true when the events were served from an expired cache entry
+	// because Yelp could not be reached, see WithStaleIfError.
+	Stale bool `json:"-"`
+}
+
+// IsValid returns true when Location and Coordinates are not both set, Limit,
+// Offset and Radius are within the bounds accepted by Yelp and EndsBefore
+// is not before StartsAfter.
+func (eo EventsOptions) IsValid() bool {
+	if eo.Location != nil && eo.Coordinates != nil {
+		return false
+	}
+
+	limit, offset := Int64Val(eo.Limit), Int64Val(eo.Offset)
+	if limit < 0 || limit > maxEventsLimit || offset < 0 || offset+limit > maxEventsWindow {
+		return false
+	}
+	if radius := Int64Val(eo.Radius); radius < 0 || radius > maxEventsRadius {
+		return false
+	}
+	return eo.StartsAfter.IsZero() || eo.EndsBefore.IsZero() ||
+		!eo.EndsBefore.Before(eo.StartsAfter)
+}
+
+// URLValues returns EventsOptions as url.Values.
+func (eo EventsOptions) URLValues() url.Values {
+	vals := url.Values{}
+	if eo.Coordinates != nil {
+		vals = eo.Coordinates.URLValues()
+	} else if eo.Location != nil {
+		vals.Add("location", *eo.Location)
+	}
+
+	if eo.Radius != nil {
+		vals.Add("radius", IntString(*eo.Radius))
+	}
+	if eo.Locale != nil {
+		vals.Add("locale", *eo.Locale)
+	}
+	if eo.Limit != nil {
+		vals.Add("limit", IntString(*eo.Limit))
+	}
+	if eo.Offset != nil {
+		vals.Add("offset", IntString(*eo.Offset))
+	}
+	if eo.SortBy != nil {
+		vals.Add("sort_by", *eo.SortBy)
+	}
+	if eo.SortOn != nil {
+		vals.Add("sort_on", *eo.SortOn)
+	}
+	if len(eo.Categories) > 0 {
+		vals.Add("categories", strings.Join(eo.Categories, ","))
+	}
+	if eo.IsFree != nil {
+		vals.Add("is_free", BoolString(*eo.IsFree))
+	}
+	if !eo.StartsAfter.IsZero() {
+		vals.Add("start_date", IntString(eo.StartsAfter.Unix()))
+	}
+	if !eo.EndsBefore.IsZero() {
+		vals.Add("end_date", IntString(eo.EndsBefore.Unix()))
+	}
+	return vals
+}
@@ -0,0 +1,162 @@
+package yelp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	// nominatimURL is the search endpoint of the public Nominatim instance
+	nominatimURL = "https://nominatim.openstreetmap.org/search"
+
+	// googleGeocodeURL is the endpoint of the Google Geocoding API
+	googleGeocodeURL = "https://maps.googleapis.com/maps/api/geocode/json"
+)
+
+// ErrNoGeocodeResult is returned by a Geocoder that found no place for an
+// address.
+var ErrNoGeocodeResult = errors.New("yelp: address not found by geocoder")
+
+// Geocoder converts an address to coordinates.
+type Geocoder interface {
+	Geocode(ctx context.Context, address string) (Coordinates, error)
+}
+
+// GeocoderFunc is an adapter to allow the use of ordinary functions as
+// Geocoder.
+type GeocoderFunc func(ctx context.Context, address string) (Coordinates, error)
+
+// Geocode calls f(ctx, address).
+func (f GeocoderFunc) Geocode(ctx context.Context, address string) (Coordinates, error) {
+	return f(ctx, address)
+}
+
+// WithGeocoder makes the client geocode the Location of searches with g and
+// search around the coordinates instead, so the radius is measured from a
+// precise point rather than from wherever Yelp resolves the location.
+func WithGeocoder(g Geocoder) Option {
+	return func(c *client) {
+		c.geocoder = g
+	}
+}
+
+// geocode replaces the Location of so with its coordinates when the client has
+// a Geocoder.
+func (c *client) geocode(ctx context.Context, so SearchOptions) (SearchOptions, error) {
+	if c.geocoder == nil || so.Location == nil {
+		return so, nil
+	}
+	coords, err := c.geocoder.Geocode(ctx, *so.Location)
+	if err != nil {
+		return so, err
+	}
+	so.Location = nil
+	so.Coordinates = &coords
+	return so, nil
+}
+
+// NominatimGeocoder is a Geocoder using the Nominatim API of OpenStreetMap.
+// The usage policy of the public instance requires a UserAgent identifying
+// the application.
+type NominatimGeocoder struct {
+	Client    *http.Client
+	BaseURL   string
+	UserAgent string
+}
+
+// Geocode returns the coordinates of the first place Nominatim finds.
+func (g NominatimGeocoder) Geocode(ctx context.Context, address string) (Coordinates, error) {
+	base := g.BaseURL
+	if base == "" {
+		base = nominatimURL
+	}
+	vals := url.Values{"q": {address}, "format": {"json"}, "limit": {"1"}}
+
+	places := []struct {
+		Lat string `json:"lat"`
+		Lon string `json:"lon"`
+	}{}
+	headers := map[string]string{"User-Agent": g.UserAgent}
+	if err := getJSON(ctx, g.Client, base+"?"+vals.Encode(), headers, &places); err != nil {
+		return Coordinates{}, err
+	}
+	if len(places) == 0 {
+		return Coordinates{}, ErrNoGeocodeResult
+	}
+
+	lat, err := strconv.ParseFloat(places[0].Lat, 64)
+	if err != nil {
+		return Coordinates{}, err
+	}
+	lon, err := strconv.ParseFloat(places[0].Lon, 64)
+	if err != nil {
+		return Coordinates{}, err
+	}
+	return Coordinates{Latitude: lat, Longitude: lon}, nil
+}
+
+// GoogleGeocoder is a Geocoder using the Google Geocoding API.
+type GoogleGeocoder struct {
+	Client *http.Client
+	APIKey string
+}
+
+// Geocode returns the coordinates of the first place Google finds.
+func (g GoogleGeocoder) Geocode(ctx context.Context, address string) (Coordinates, error) {
+	vals := url.Values{"address": {address}, "key": {g.APIKey}}
+
+	resp := struct {
+		Status  string `json:"status"`
+		Results []struct {
+			Geometry struct {
+				Location struct {
+					Lat float64 `json:"lat"`
+					Lng float64 `json:"lng"`
+				} `json:"location"`
+			} `json:"geometry"`
+		} `json:"results"`
+	}{}
+	if err := getJSON(ctx, g.Client, googleGeocodeURL+"?"+vals.Encode(), nil, &resp); err != nil {
+		return Coordinates{}, err
+	}
+	switch {
+	case resp.Status == "ZERO_RESULTS" || (resp.Status == "OK" && len(resp.Results) == 0):
+		return Coordinates{}, ErrNoGeocodeResult
+	case resp.Status != "OK":
+		return Coordinates{}, fmt.Errorf("yelp: Google geocoding failed with status %s", resp.Status)
+	}
+
+	loc := resp.Results[0].Geometry.Location
+	return Coordinates{Latitude: loc.Lat, Longitude: loc.Lng}, nil
+}
+
+// getJSON makes a GET request with c, or http.DefaultClient when nil, and
+// decodes the response body into v.
+func getJSON(ctx context.Context, c *http.Client, url string, headers map[string]string, v interface{}) error {
+	if c == nil {
+		c = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+	for key, val := range headers {
+		req.Header.Set(key, val)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("request to %s failed with status %s", req.URL.Host, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,79 @@
+package yelp
+
+import "strings"
+
+// GeoJSONFeatureCollection is a GeoJSON FeatureCollection, ready to be
+// encoded with encoding/json.
+type GeoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []GeoJSONFeature `json:"features"`
+}
+
+// GeoJSONFeature is a GeoJSON Feature.
+type GeoJSONFeature struct {
+	Type       string                 `json:"type"`
+	ID         string                 `json:"id,omitempty"`
+	Geometry   GeoJSONPoint           `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// GeoJSONPoint is a GeoJSON Point geometry. Coordinates are longitude then
+// latitude.
+type GeoJSONPoint struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+// ToGeoJSON returns the businesses as a FeatureCollection of points with the
+// main fields of each business as properties. Businesses without coordinates
+// are left out.
+func (sr SearchResults) ToGeoJSON() GeoJSONFeatureCollection {
+	return BusinessesGeoJSON(sr.Businesses)
+}
+
+// BusinessesGeoJSON returns businesses as a FeatureCollection, see
+// SearchResults.ToGeoJSON.
+func BusinessesGeoJSON(businesses []Business) GeoJSONFeatureCollection {
+	fc := GeoJSONFeatureCollection{
+		Type:     "FeatureCollection",
+		Features: []GeoJSONFeature{},
+	}
+	for _, b := range businesses {
+		if !b.Coodinates.isSet() {
+			continue
+		}
+
+		categories := make([]string, len(b.Categories))
+		for i, c := range b.Categories {
+			categories[i] = c.Title
+		}
+		fc.Features = append(fc.Features, GeoJSONFeature{
+			Type: "Feature",
+			ID:   b.ID,
+			Geometry: GeoJSONPoint{
+				Type:        "Point",
+				Coordinates: [2]float64{b.Coodinates.Longitude, b.Coodinates.Latitude},
+			},
+			Properties: map[string]interface{}{
+				"name":         b.Name,
+				"url":          b.URL,
+				"image_url":    b.ImageURL,
+				"rating":       b.Rating,
+				"review_count": b.ReviewCount,
+				"price":        b.Price,
+				"phone":        b.DisplayPhone,
+				"address":      strings.Join(b.Location.DisplayAddress, ", "),
+				"categories":   categories,
+				"is_closed":    b.IsClosed,
+				"distance":     b.Distance,
+			},
+		})
+	}
+	return fc
+}
+
+// isSet returns true when the coordinates are not the zero value Yelp leaves
+// for businesses without a known location.
+func (c Coordinates) isSet() bool {
+	return c.Latitude != 0 || c.Longitude != 0
+}
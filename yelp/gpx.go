@@ -0,0 +1,60 @@
+package yelp
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// gpxNamespace is the XML namespace of GPX 1.1.
+const gpxNamespace = "http://www.topografix.com/GPX/1/1"
+
+// gpxDocument is the root of a GPX file.
+type gpxDocument struct {
+	XMLName   xml.Name      `xml:"gpx"`
+	Xmlns     string        `xml:"xmlns,attr"`
+	Version   string        `xml:"version,attr"`
+	Creator   string        `xml:"creator,attr"`
+	Waypoints []gpxWaypoint `xml:"wpt"`
+}
+
+// gpxWaypoint is a business in a GPX file.
+type gpxWaypoint struct {
+	Lat         float64  `xml:"lat,attr"`
+	Lon         float64  `xml:"lon,attr"`
+	Name        string   `xml:"name"`
+	Description string   `xml:"desc,omitempty"`
+	Link        *gpxLink `xml:"link,omitempty"`
+	Type        string   `xml:"type,omitempty"`
+}
+
+// gpxLink is a link to the Yelp page of a business.
+type gpxLink struct {
+	Href string `xml:"href,attr"`
+	Text string `xml:"text,omitempty"`
+}
+
+// WriteGPX writes businesses to w as GPX waypoints, for GPS devices and
+// travel planners. Businesses without coordinates are left out.
+func WriteGPX(w io.Writer, businesses []Business) error {
+	doc := gpxDocument{Xmlns: gpxNamespace, Version: "1.1", Creator: "go-yelp"}
+	for _, b := range businesses {
+		if !b.Coodinates.isSet() {
+			continue
+		}
+
+		wpt := gpxWaypoint{
+			Lat:         b.Coodinates.Latitude,
+			Lon:         b.Coodinates.Longitude,
+			Name:        b.Name,
+			Description: businessSummary(b),
+		}
+		if b.URL != "" {
+			wpt.Link = &gpxLink{Href: b.URL, Text: "Yelp"}
+		}
+		if len(b.Categories) > 0 {
+			wpt.Type = b.Categories[0].Title
+		}
+		doc.Waypoints = append(doc.Waypoints, wpt)
+	}
+	return writeXML(w, doc)
+}
@@ -0,0 +1,229 @@
+package yelp
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// redacted replaces secrets in recorded requests.
+const redacted = "REDACTED"
+
+// HARRecorder records the requests sent by a client and their responses in
+// the HTTP Archive (HAR) 1.2 format, with the Authorization header redacted,
+// so sessions can be shared for debugging.
+type HARRecorder struct {
+	mu      sync.Mutex
+	entries []HAREntry
+}
+
+// HAR is the root of an HTTP Archive.
+type HAR struct {
+	Log HARLog `json:"log"`
+}
+
+// HARLog is the log of an HTTP Archive.
+type HARLog struct {
+	Version string     `json:"version"`
+	Creator HARCreator `json:"creator"`
+	Entries []HAREntry `json:"entries"`
+}
+
+// HARCreator is the application that created an HTTP Archive.
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// HAREntry is a request and its response.
+type HAREntry struct {
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         HARRequest  `json:"request"`
+	Response        HARResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         HARTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"`
+}
+
+// HARRequest is a recorded request.
+type HARRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	QueryString []HARNameValue `json:"queryString"`
+	PostData    *HARPostData   `json:"postData,omitempty"`
+	HeadersSize int64          `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+}
+
+// HARResponse is a recorded response. Status is 0 when no response was
+// received.
+type HARResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	Content     HARContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int64          `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+}
+
+// HARNameValue is a header, cookie or query string parameter.
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HARPostData is the body of a recorded request.
+type HARPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// HARContent is the body of a recorded response.
+type HARContent struct {
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// HARTimings are the durations of a request in milliseconds.
+type HARTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// NewHARRecorder returns an empty HARRecorder.
+func NewHARRecorder() *HARRecorder {
+	return &HARRecorder{}
+}
+
+// WithHARRecorder makes the client record every request it sends in r.
+func WithHARRecorder(r *HARRecorder) Option {
+	return func(c *client) {
+		c.har = r
+	}
+}
+
+// HAR returns the requests recorded so far.
+func (r *HARRecorder) HAR() HAR {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entries := make([]HAREntry, len(r.entries))
+	copy(entries, r.entries)
+	return HAR{
+		Log: HARLog{
+			Version: "1.2",
+			Creator: HARCreator{Name: "go-yelp", Version: "1.0"},
+			Entries: entries,
+		},
+	}
+}
+
+// WriteTo writes the requests recorded so far to w as JSON.
+func (r *HARRecorder) WriteTo(w io.Writer) (int64, error) {
+	b, err := json.MarshalIndent(r.HAR(), "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// Reset discards the requests recorded so far.
+func (r *HARRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = nil
+}
+
+// record adds req and its response, which took elapsed from started, to the
+// log. resp is nil when the request failed with err.
+func (r *HARRecorder) record(req *http.Request, resp *http.Response, body []byte, err error, started time.Time, elapsed time.Duration) {
+	ms := float64(elapsed) / float64(time.Millisecond)
+	entry := HAREntry{
+		StartedDateTime: started,
+		Time:            ms,
+		Request:         harRequest(req),
+		Response: HARResponse{
+			Cookies:     []HARNameValue{},
+			Headers:     []HARNameValue{},
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+		Timings: HARTimings{Wait: ms},
+	}
+	if err != nil {
+		entry.Comment = err.Error()
+	}
+	if resp != nil {
+		entry.Response = HARResponse{
+			Status:      resp.StatusCode,
+			StatusText:  http.StatusText(resp.StatusCode),
+			HTTPVersion: resp.Proto,
+			Cookies:     []HARNameValue{},
+			Headers:     harHeaders(resp.Header),
+			Content: HARContent{
+				Size:     int64(len(body)),
+				MimeType: resp.Header.Get("Content-Type"),
+				Text:     string(body),
+			},
+			HeadersSize: -1,
+			BodySize:    int64(len(body)),
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, entry)
+}
+
+// harRequest returns req as a HARRequest.
+func harRequest(req *http.Request) HARRequest {
+	hr := HARRequest{
+		Method:      req.Method,
+		URL:         req.URL.String(),
+		HTTPVersion: req.Proto,
+		Cookies:     []HARNameValue{},
+		Headers:     harHeaders(req.Header),
+		QueryString: []HARNameValue{},
+		HeadersSize: -1,
+	}
+	for name, vals := range req.URL.Query() {
+		for _, val := range vals {
+			hr.QueryString = append(hr.QueryString, HARNameValue{Name: name, Value: val})
+		}
+	}
+	if req.GetBody != nil {
+		if rc, err := req.GetBody(); err == nil {
+			b, _ := io.ReadAll(rc)
+			rc.Close()
+			hr.BodySize = int64(len(b))
+			hr.PostData = &HARPostData{MimeType: req.Header.Get("Content-Type"), Text: string(b)}
+		}
+	}
+	return hr
+}
+
+// harHeaders returns h as HARNameValues with the Authorization header redacted.
+func harHeaders(h http.Header) []HARNameValue {
+	nvs := []HARNameValue{}
+	for name, vals := range h {
+		for _, val := range vals {
+			if http.CanonicalHeaderKey(name) == "Authorization" {
+				val = redacted
+			}
+			nvs = append(nvs, HARNameValue{Name: name, Value: val})
+		}
+	}
+	return nvs
+}
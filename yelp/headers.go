@@ -0,0 +1,49 @@
+package yelp
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// headersKey is the context key of the extra request headers.
+type headersKey struct{}
+
+// WithHeaders returns a copy of ctx carrying h, headers added to the requests
+// made with it, such as X-Request-ID or a correlation id. Headers already in
+// ctx are kept unless h sets them too. The Authorization header cannot be
+// overridden.
+func WithHeaders(ctx context.Context, h http.Header) context.Context {
+	merged := HeadersFromContext(ctx).Clone()
+	if merged == nil {
+		merged = http.Header{}
+	}
+	for key, vals := range h {
+		merged[http.CanonicalHeaderKey(key)] = append([]string(nil), vals...)
+	}
+	return context.WithValue(ctx, headersKey{}, merged)
+}
+
+// HeadersFromContext returns the extra request headers of ctx, or nil when it
+// has none. The returned headers must not be modified.
+func HeadersFromContext(ctx context.Context) http.Header {
+	h, _ := ctx.Value(headersKey{}).(http.Header)
+	return h
+}
+
+// withContextHeaders returns headers with the extra headers of ctx added.
+func withContextHeaders(ctx context.Context, headers map[string]string) map[string]string {
+	extra := HeadersFromContext(ctx)
+	if len(extra) == 0 {
+		return headers
+	}
+
+	merged := make(map[string]string, len(headers)+len(extra))
+	for key, vals := range extra {
+		merged[key] = strings.Join(vals, ", ")
+	}
+	for key, val := range headers {
+		merged[key] = val
+	}
+	return merged
+}
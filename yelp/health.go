@@ -0,0 +1,47 @@
+package yelp
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// HealthScore is the health inspection data Yelp returns on business details
+// for some regions.
+type HealthScore struct {
+	Score          *float64 `json:"score"`
+	Grade          string   `json:"grade"`
+	InspectionDate string   `json:"inspection_date"`
+	URL            string   `json:"url"`
+}
+
+// UnmarshalJSON decodes health inspection data leniently: the score may be a
+// number or a string and malformed data is ignored instead of failing to
+// decode the whole business.
+func (hs *HealthScore) UnmarshalJSON(data []byte) error {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil
+	}
+
+	*hs = HealthScore{}
+	json.Unmarshal(fields["grade"], &hs.Grade)
+	json.Unmarshal(fields["inspection_date"], &hs.InspectionDate)
+	json.Unmarshal(fields["url"], &hs.URL)
+
+	raw := fields["score"]
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	var score float64
+	if err := json.Unmarshal(raw, &score); err == nil {
+		hs.Score = &score
+		return nil
+	}
+	var str string
+	if err := json.Unmarshal(raw, &str); err == nil {
+		if f, err := strconv.ParseFloat(str, 64); err == nil {
+			hs.Score = &f
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package yelp
+
+import (
+	"math"
+	"sort"
+)
+
+// HeatmapCell is a cell of a Heatmap grid with the businesses within it.
+type HeatmapCell struct {
+	Bounds        Bounds
+	Count         int
+	AverageRating float64
+}
+
+// Center returns the center of the cell.
+func (hc HeatmapCell) Center() Coordinates {
+	return Coordinates{
+		Latitude:  (hc.Bounds.SouthWest.Latitude + hc.Bounds.NorthEast.Latitude) / 2,
+		Longitude: (hc.Bounds.SouthWest.Longitude + hc.Bounds.NorthEast.Longitude) / 2,
+	}
+}
+
+// Heatmap bins the businesses into a grid of cells of cellDegrees of latitude
+// and longitude, aligned on multiples of cellDegrees, and returns the cells
+// with at least one business, from south west to north east, for density
+// heatmaps. Businesses without coordinates are left out.
+func Heatmap(businesses []Business, cellDegrees float64) []HeatmapCell {
+	if cellDegrees <= 0 {
+		return []HeatmapCell{}
+	}
+
+	type key struct{ lat, lng int64 }
+	type bin struct {
+		count int
+		sum   float64
+	}
+	bins := map[key]*bin{}
+	for _, b := range businesses {
+		if !b.Coodinates.isSet() {
+			continue
+		}
+		k := key{
+			lat: int64(math.Floor(b.Coodinates.Latitude / cellDegrees)),
+			lng: int64(math.Floor(b.Coodinates.Longitude / cellDegrees)),
+		}
+		if bins[k] == nil {
+			bins[k] = &bin{}
+		}
+		bins[k].count++
+		bins[k].sum += b.Rating
+	}
+
+	keys := make([]key, 0, len(bins))
+	for k := range bins {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].lat != keys[j].lat {
+			return keys[i].lat < keys[j].lat
+		}
+		return keys[i].lng < keys[j].lng
+	})
+
+	cells := make([]HeatmapCell, len(keys))
+	for i, k := range keys {
+		sw := Coordinates{Latitude: float64(k.lat) * cellDegrees, Longitude: float64(k.lng) * cellDegrees}
+		cells[i] = HeatmapCell{
+			Bounds: Bounds{
+				SouthWest: sw,
+				NorthEast: Coordinates{Latitude: sw.Latitude + cellDegrees, Longitude: sw.Longitude + cellDegrees},
+			},
+			Count:         bins[k].count,
+			AverageRating: bins[k].sum / float64(bins[k].count),
+		}
+	}
+	return cells
+}
@@ -0,0 +1,162 @@
+package yelp
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// hedgeWindow is the number of latencies per endpoint family the hedging
+	// threshold is computed from.
+	hedgeWindow = 256
+
+	// minHedgeSamples is the number of latencies needed before hedging.
+	minHedgeSamples = 20
+)
+
+// HedgePolicy defines when a duplicate of a slow request is sent.
+type HedgePolicy struct {
+	// Percentile of the recent latencies of the endpoint family after which
+	// a duplicate request is sent, e.g. 0.95. 0.95 when zero.
+	Percentile float64
+
+	// MinDelay is the least wait before sending a duplicate request.
+	MinDelay time.Duration
+
+	// MaxRatio is the largest share of the requests that can be duplicated,
+	// which bounds the quota spent on hedging, e.g. 0.05 for 5%. 0.05 when
+	// zero.
+	MaxRatio float64
+}
+
+// WithHedging makes the client send a duplicate of the GET requests taking
+// longer than most, following p, and use the first response, to cut the
+// tail latency. Hedging starts once enough latencies of the endpoint family
+// are known. Both requests count against the quota.
+func WithHedging(p HedgePolicy) Option {
+	return func(c *client) {
+		if p.Percentile <= 0 || p.Percentile >= 1 {
+			p.Percentile = 0.95
+		}
+		if p.MaxRatio <= 0 {
+			p.MaxRatio = 0.05
+		}
+		c.hedger = &hedger{policy: p, latencies: map[Endpoint][]time.Duration{}}
+	}
+}
+
+// hedger tracks the latencies and the budget of hedged requests.
+type hedger struct {
+	policy HedgePolicy
+
+	mu        sync.Mutex
+	latencies map[Endpoint][]time.Duration
+	sent      int
+	hedged    int
+}
+
+// observe records the latency of a request of the endpoint family.
+func (h *hedger) observe(ep Endpoint, d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	l := append(h.latencies[ep], d)
+	if len(l) > hedgeWindow {
+		l = l[len(l)-hedgeWindow:]
+	}
+	h.latencies[ep] = l
+}
+
+// delay returns the wait before hedging a request of the endpoint family and
+// counts the request. ok is false when it cannot be hedged.
+func (h *hedger) delay(ep Endpoint) (d time.Duration, ok bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.sent++
+	l := h.latencies[ep]
+	if len(l) < minHedgeSamples {
+		return 0, false
+	}
+	sorted := append([]time.Duration(nil), l...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	d = sorted[int(h.policy.Percentile*float64(len(sorted)-1))]
+	if d < h.policy.MinDelay {
+		d = h.policy.MinDelay
+	}
+	return d, true
+}
+
+// reserve counts a hedged request, returning false when the budget is spent.
+func (h *hedger) reserve() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if float64(h.hedged+1) > h.policy.MaxRatio*float64(h.sent) {
+		return false
+	}
+	h.hedged++
+	return true
+}
+
+// hedgeResult is the outcome of one of the requests of sendHedged.
+type hedgeResult struct {
+	resp *http.Response
+	raw  json.RawMessage
+	err  error
+}
+
+// sendHedged sends req, and a duplicate of it when it takes longer than the
+// hedging threshold, decoding the first response into v.
+func (c *client) sendHedged(req *http.Request, ep Endpoint, v interface{}) (*http.Response, error) {
+	h := c.hedger
+	wait, ok := h.delay(ep)
+	if req.Method != http.MethodGet || !ok {
+		started := c.clock.Now()
+		resp, err := c.send(req, ep, v)
+		if err == nil {
+			h.observe(ep, c.clock.Now().Sub(started))
+		}
+		return resp, err
+	}
+
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+	results := make(chan hedgeResult, 2)
+	started := c.clock.Now()
+	launch := func() {
+		go func() {
+			r := hedgeResult{}
+			r.resp, r.err = c.send(req.Clone(ctx), ep, &r.raw)
+			results <- r
+		}()
+	}
+	launch()
+
+	inflight := 1
+	timer := c.clock.NewTimer(wait)
+	defer timer.Stop()
+	var first *hedgeResult
+	for inflight > 0 {
+		select {
+		case <-timer.C():
+			if h.reserve() {
+				c.log(ctx, slog.LevelDebug, "yelp: hedging request", "endpoint", ep, "after", wait)
+				launch()
+				inflight++
+			}
+		case r := <-results:
+			inflight--
+			if r.err == nil {
+				h.observe(ep, c.clock.Now().Sub(started))
+				return r.resp, json.Unmarshal(r.raw, v)
+			}
+			if first == nil {
+				first = &r
+			}
+		}
+	}
+	return first.resp, first.err
+}
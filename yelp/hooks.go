@@ -0,0 +1,35 @@
+package yelp
+
+import "net/http"
+
+// RequestHook is called with every request before it is sent. It can change
+// the request, e.g. to sign it or replace its Authorization header, and
+// abort it by returning an error, which is returned by the call.
+type RequestHook func(*http.Request) error
+
+// WithRequestHook makes the client call hook with every request it sends,
+// retries included, e.g. to go through an egress gateway that requires
+// signed requests:
+//
+//	yelp.WithRequestHook(func(req *http.Request) error {
+//		req.Header.Set("X-Signature", sign(req))
+//		return nil
+//	})
+//
+// Hooks are called in the order they were added, after the Authorization
+// header and the headers of the call are set.
+func WithRequestHook(hook RequestHook) Option {
+	return func(c *client) {
+		c.hooks = append(c.hooks, hook)
+	}
+}
+
+// runHooks calls the request hooks with req.
+func (c *client) runHooks(req *http.Request) error {
+	for _, hook := range c.hooks {
+		if err := hook(req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package yelp
+
+import "time"
+
+// Hours types returned by Yelp.
+const (
+	HoursTypeRegular = "REGULAR"
+)
+
+// Hours are the weekly opening hours of a business.
+type Hours struct {
+	Open      []OpenHours `json:"open"`
+	HoursType string      `json:"hours_type"`
+	IsOpenNow bool        `json:"is_open_now"`
+}
+
+// OpenHours is a time range a business is open on a day of the week. Day is
+// 0 for Monday to 6 for Sunday and Start and End are local times as HHMM,
+// e.g. "1730". The range ends on the next day when IsOvernight.
+type OpenHours struct {
+	IsOvernight bool   `json:"is_overnight"`
+	Start       string `json:"start"`
+	End         string `json:"end"`
+	Day         int    `json:"day"`
+}
+
+// SpecialHours are the hours of a business on a date, such as a holiday,
+// overriding its weekly hours. Date is formatted as YYYY-MM-DD.
+type SpecialHours struct {
+	Date        string `json:"date"`
+	IsClosed    *bool  `json:"is_closed"`
+	Start       string `json:"start"`
+	End         string `json:"end"`
+	IsOvernight bool   `json:"is_overnight"`
+}
+
+// RegularHours returns the weekly hours of the business, or nil when Yelp
+// did not report them, as in search results.
+func (b Business) RegularHours() []OpenHours {
+	for _, h := range b.Hours {
+		if h.HoursType == HoursTypeRegular || h.HoursType == "" {
+			return h.Open
+		}
+	}
+	return nil
+}
+
+// Meal periods of the meal predicates, as times of day.
+const (
+	breakfastStart = 7 * time.Hour
+	breakfastEnd   = 10 * time.Hour
+	lunchStart     = 11*time.Hour + 30*time.Minute
+	lunchEnd       = 14 * time.Hour
+	dinnerStart    = 18 * time.Hour
+	dinnerEnd      = 21 * time.Hour
+)
+
+// BusinessFilter returns true for the businesses to keep.
+type BusinessFilter func(Business) bool
+
+// Filter returns the businesses all the filters return true for.
+func Filter(businesses []Business, filters ...BusinessFilter) []Business {
+	kept := []Business{}
+next:
+	for _, b := range businesses {
+		for _, f := range filters {
+			if !f(b) {
+				continue next
+			}
+		}
+		kept = append(kept, b)
+	}
+	return kept
+}
+
+// OpenAfter returns a BusinessFilter keeping the businesses open past the time
+// of day on at least one day, e.g. OpenAfter(22*time.Hour) for places open
+// late. Like the other hours filters, it needs the hours of the businesses,
+// returned by BusinessByID but not by Search, and drops the ones without.
+func OpenAfter(timeOfDay time.Duration) BusinessFilter {
+	return func(b Business) bool {
+		for _, oh := range b.RegularHours() {
+			_, end, ok := openRange(oh)
+			if ok && end > timeOfDay {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// OpenDuring returns a BusinessFilter keeping the businesses open at some
+// point between the times of day from and to on at least one day.
+func OpenDuring(from, to time.Duration) BusinessFilter {
+	return func(b Business) bool {
+		for _, oh := range b.RegularHours() {
+			start, end, ok := openRange(oh)
+			if !ok {
+				continue
+			}
+			// Overnight ranges also cover the early hours of the next day.
+			if (start < to && end > from) || (end > 24*time.Hour && end-24*time.Hour > from) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// OpenForBreakfast returns a BusinessFilter keeping the businesses open
+// between 7 and 10 am on at least one day.
+func OpenForBreakfast() BusinessFilter {
+	return OpenDuring(breakfastStart, breakfastEnd)
+}
+
+// OpenForLunch returns a BusinessFilter keeping the businesses open between
+// 11:30 am and 2 pm on at least one day.
+func OpenForLunch() BusinessFilter {
+	return OpenDuring(lunchStart, lunchEnd)
+}
+
+// OpenForDinner returns a BusinessFilter keeping the businesses open between
+// 6 and 9 pm on at least one day.
+func OpenForDinner() BusinessFilter {
+	return OpenDuring(dinnerStart, dinnerEnd)
+}
+
+// OpenAt returns a BusinessFilter keeping the businesses open at the weekday
+// and the time of day of t, in the time zone of the businesses, or at the
+// special hours of its date.
+func OpenAt(t time.Time) BusinessFilter {
+	day := yelpDay(t.Weekday())
+	tod := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	date := t.Format("2006-01-02")
+	prevDate := t.AddDate(0, 0, -1).Format("2006-01-02")
+	return func(b Business) bool {
+		if special, ok := specialOpen(b, date, prevDate, tod); ok {
+			return special
+		}
+		for _, oh := range b.RegularHours() {
+			start, end, ok := openRange(oh)
+			if !ok {
+				continue
+			}
+			if oh.Day == day && tod >= start && tod < end {
+				return true
+			}
+			if oh.Day == (day+6)%7 && end > 24*time.Hour && tod < end-24*time.Hour {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// specialOpen returns whether the business is open at tod on date by its
+// special hours, ok false when it has none that day.
+func specialOpen(b Business, date, prevDate string, tod time.Duration) (open, ok bool) {
+	for _, sh := range b.SpecialHours {
+		if sh.Date != date && sh.Date != prevDate {
+			continue
+		}
+		start, end, valid := openRange(OpenHours{IsOvernight: sh.IsOvernight, Start: sh.Start, End: sh.End})
+		switch {
+		case sh.Date == date:
+			ok = true
+			if !BoolVal(sh.IsClosed) && valid && tod >= start && tod < end {
+				return true, true
+			}
+		case !BoolVal(sh.IsClosed) && valid && end > 24*time.Hour && tod < end-24*time.Hour:
+			return true, true
+		}
+	}
+	return false, ok
+}
+
+// openRange returns the times of day the range opens and closes, the close
+// past 24 hours when it ends on the next day.
+func openRange(oh OpenHours) (start, end time.Duration, ok bool) {
+	s, ok1 := hhmm(oh.Start)
+	e, ok2 := hhmm(oh.End)
+	if !ok1 || !ok2 {
+		return 0, 0, false
+	}
+	if oh.IsOvernight || e <= s {
+		e += 24 * time.Hour
+	}
+	return s, e, true
+}
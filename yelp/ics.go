@@ -0,0 +1,169 @@
+package yelp
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// icsDate and icsDateTime are the layouts of iCalendar dates and floating
+// local date-times.
+const (
+	icsDate     = "20060102"
+	icsDateTime = "20060102T150405"
+)
+
+// icsDays are the iCalendar names of the days of Yelp hours, from Monday.
+var icsDays = []string{"MO", "TU", "WE", "TH", "FR", "SA", "SU"}
+
+// WriteICS writes the hours of the business to w as an iCalendar feed: a
+// weekly recurring event per opening range, starting the week of from, and
+// an event per special hours date, which replaces the weekly ones that day.
+// Yelp does not report time zones, so times are floating local times,
+// shown at the same clock time in any time zone. from is also the stamp of
+// the events.
+func WriteICS(w io.Writer, b Business, from time.Time) error {
+	iw := &icsWriter{w: bufio.NewWriter(w)}
+	iw.line("BEGIN:VCALENDAR")
+	iw.line("VERSION:2.0")
+	iw.line("PRODID:-//go-yelp//business hours//EN")
+	iw.line("CALSCALE:GREGORIAN")
+	iw.line("X-WR-CALNAME:" + icsText(b.Name))
+
+	stamp := from.UTC().Format(icsDateTime) + "Z"
+	special := make([]string, 0, len(b.SpecialHours))
+	for _, sh := range b.SpecialHours {
+		special = append(special, sh.Date)
+	}
+	sort.Strings(special)
+
+	for _, oh := range b.RegularHours() {
+		if oh.Day < 0 || oh.Day >= len(icsDays) {
+			continue
+		}
+		start, end, ok := hoursRange(weekdayFrom(from, oh.Day), oh.Start, oh.End, oh.IsOvernight)
+		if !ok {
+			continue
+		}
+		iw.line("BEGIN:VEVENT")
+		iw.line(fmt.Sprintf("UID:%s-%s-%s@go-yelp", b.ID, icsDays[oh.Day], oh.Start))
+		iw.line("DTSTAMP:" + stamp)
+		iw.line("SUMMARY:" + icsText(b.Name+" open"))
+		iw.line("DTSTART:" + start.Format(icsDateTime))
+		iw.line("DTEND:" + end.Format(icsDateTime))
+		iw.line("RRULE:FREQ=WEEKLY;BYDAY=" + icsDays[oh.Day])
+		for _, date := range special {
+			d, err := time.Parse("2006-01-02", date)
+			if err == nil && !d.Before(start.Truncate(24*time.Hour)) && yelpDay(d.Weekday()) == oh.Day {
+				iw.line("EXDATE:" + d.Format(icsDate) + start.Format("T150405"))
+			}
+		}
+		iw.location(b)
+		iw.line("END:VEVENT")
+	}
+
+	for _, sh := range b.SpecialHours {
+		d, err := time.Parse("2006-01-02", sh.Date)
+		if err != nil {
+			continue
+		}
+		iw.line("BEGIN:VEVENT")
+		iw.line(fmt.Sprintf("UID:%s-%s@go-yelp", b.ID, d.Format(icsDate)))
+		iw.line("DTSTAMP:" + stamp)
+		start, end, ok := hoursRange(d, sh.Start, sh.End, sh.IsOvernight)
+		if BoolVal(sh.IsClosed) || !ok {
+			iw.line("SUMMARY:" + icsText(b.Name+" closed"))
+			iw.line("DTSTART;VALUE=DATE:" + d.Format(icsDate))
+			iw.line("DTEND;VALUE=DATE:" + d.AddDate(0, 0, 1).Format(icsDate))
+		} else {
+			iw.line("SUMMARY:" + icsText(b.Name+" open (special hours)"))
+			iw.line("DTSTART:" + start.Format(icsDateTime))
+			iw.line("DTEND:" + end.Format(icsDateTime))
+		}
+		iw.location(b)
+		iw.line("END:VEVENT")
+	}
+
+	iw.line("END:VCALENDAR")
+	if iw.err != nil {
+		return iw.err
+	}
+	return iw.w.Flush()
+}
+
+// icsWriter writes folded iCalendar lines, keeping the first error.
+type icsWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+// line writes s folded at 75 octets, without splitting UTF-8 sequences.
+func (iw *icsWriter) line(s string) {
+	if iw.err != nil {
+		return
+	}
+	for len(s) > 75 {
+		cut := 75
+		for cut > 0 && s[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		if _, iw.err = iw.w.WriteString(s[:cut] + "\r\n "); iw.err != nil {
+			return
+		}
+		s = s[cut:]
+	}
+	_, iw.err = iw.w.WriteString(s + "\r\n")
+}
+
+// location writes the address and the link of the business.
+func (iw *icsWriter) location(b Business) {
+	if addr := strings.Join(b.Location.DisplayAddress, ", "); addr != "" {
+		iw.line("LOCATION:" + icsText(addr))
+	}
+	if b.URL != "" {
+		iw.line("URL:" + b.URL)
+	}
+}
+
+// icsText escapes s for an iCalendar text value.
+func icsText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// hoursRange returns the times on day of the HHMM start and end, the end on
+// the next day when overnight or not after the start.
+func hoursRange(day time.Time, start, end string, overnight bool) (time.Time, time.Time, bool) {
+	s, ok1 := hhmm(start)
+	e, ok2 := hhmm(end)
+	if !ok1 || !ok2 {
+		return time.Time{}, time.Time{}, false
+	}
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	from, to := day.Add(s), day.Add(e)
+	if overnight || !to.After(from) {
+		to = to.AddDate(0, 0, 1)
+	}
+	return from, to, true
+}
+
+// hhmm returns the time of day of an HHMM time.
+func hhmm(s string) (time.Duration, bool) {
+	t, err := time.Parse("1504", s)
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, true
+}
+
+// yelpDay returns the Yelp day of wd, 0 for Monday.
+func yelpDay(wd time.Weekday) int {
+	return (int(wd) + 6) % 7
+}
+
+// weekdayFrom returns the first date on or after from that is the Yelp day.
+func weekdayFrom(from time.Time, day int) time.Time {
+	return from.AddDate(0, 0, (day-yelpDay(from.Weekday())+7)%7)
+}
@@ -0,0 +1,133 @@
+package yelp
+
+import (
+	_ "embed"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// taxonomyJSON is the part of the Yelp category list the intents are drawn
+// from, in the format of the categories.json file published by Yelp.
+//
+//go:embed taxonomy.json
+var taxonomyJSON []byte
+
+// taxonomyCategory is a category of the embedded taxonomy.
+type taxonomyCategory struct {
+	Alias   string   `json:"alias"`
+	Title   string   `json:"title"`
+	Parents []string `json:"parents"`
+}
+
+var (
+	taxonomyOnce     sync.Once
+	taxonomyChildren map[string][]string
+)
+
+// categoryChildren returns the aliases of the children of the category alias
+// in the embedded taxonomy, in order.
+func categoryChildren(alias string) []string {
+	taxonomyOnce.Do(func() {
+		categories := []taxonomyCategory{}
+		if err := json.Unmarshal(taxonomyJSON, &categories); err != nil {
+			panic("yelp: invalid embedded taxonomy: " + err.Error())
+		}
+		taxonomyChildren = map[string][]string{}
+		for _, cat := range categories {
+			for _, parent := range cat.Parents {
+				taxonomyChildren[parent] = append(taxonomyChildren[parent], cat.Alias)
+			}
+		}
+		for _, children := range taxonomyChildren {
+			sort.Strings(children)
+		}
+	})
+	return taxonomyChildren[alias]
+}
+
+// intentCategories maps dietary intents to the Yelp category aliases of the
+// places serving them. Each category stands for its descendants in the
+// taxonomy too, e.g. mideastern for turkish and lebanese.
+var intentCategories = map[string][]string{
+	"vegan":       {"vegan", "vegetarian", "raw_food", "salad", "juicebars"},
+	"vegetarian":  {"vegetarian", "vegan", "raw_food", "salad", "falafel"},
+	"gluten_free": {"gluten_free"},
+	"halal":       {"halal", "mideastern", "pakistani"},
+	"kosher":      {"kosher"},
+	"healthy":     {"salad", "juicebars", "acaibowls", "poke", "vegan", "vegetarian", "raw_food"},
+	"pescatarian": {"seafood", "sushi", "poke", "vegetarian", "vegan"},
+	"raw":         {"raw_food", "juicebars", "vegan"},
+}
+
+// Intents returns the dietary intents known to ExpandIntent, in order.
+func Intents() []string {
+	intents := make([]string, 0, len(intentCategories))
+	for intent := range intentCategories {
+		intents = append(intents, intent)
+	}
+	sort.Strings(intents)
+	return intents
+}
+
+// ExpandIntent returns the category aliases related to a dietary intent such
+// as "vegan" or "gluten-free", with their descendants in the Yelp category
+// taxonomy, or nil when the intent is unknown. Intents are matched ignoring
+// case, with spaces and hyphens as underscores.
+func ExpandIntent(intent string) []string {
+	key := strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(intent)))
+	roots, ok := intentCategories[key]
+	if !ok {
+		return nil
+	}
+	seen := map[string]bool{}
+	aliases := []string{}
+	var add func(alias string)
+	add = func(alias string) {
+		if seen[alias] {
+			return
+		}
+		seen[alias] = true
+		aliases = append(aliases, alias)
+		for _, child := range categoryChildren(alias) {
+			add(child)
+		}
+	}
+	for _, alias := range roots {
+		add(alias)
+	}
+	return aliases
+}
+
+// WithIntents returns a copy of so with the categories of the intents, see
+// ExpandIntent, added to its Categories. Unknown intents are used as category
+// aliases.
+func (so SearchOptions) WithIntents(intents ...string) SearchOptions {
+	seen := map[string]bool{}
+	categories := []string{}
+	add := func(alias string) {
+		if alias != "" && !seen[alias] {
+			seen[alias] = true
+			categories = append(categories, alias)
+		}
+	}
+	if so.Categories != nil {
+		for _, alias := range strings.Split(*so.Categories, ",") {
+			add(strings.TrimSpace(alias))
+		}
+	}
+	for _, intent := range intents {
+		aliases := ExpandIntent(intent)
+		if aliases == nil {
+			aliases = []string{strings.TrimSpace(intent)}
+		}
+		for _, alias := range aliases {
+			add(alias)
+		}
+	}
+	if len(categories) > 0 {
+		so.Categories = StringPtr(strings.Join(categories, ","))
+	}
+	return so
+}
@@ -0,0 +1,73 @@
+package yelp
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+// kmlNamespace is the XML namespace of KML 2.2.
+const kmlNamespace = "http://www.opengis.net/kml/2.2"
+
+// kmlDocument is the root of a KML file.
+type kmlDocument struct {
+	XMLName    xml.Name       `xml:"kml"`
+	Xmlns      string         `xml:"xmlns,attr"`
+	Placemarks []kmlPlacemark `xml:"Document>Placemark"`
+}
+
+// kmlPlacemark is a business in a KML file.
+type kmlPlacemark struct {
+	ID          string `xml:"id,attr,omitempty"`
+	Name        string `xml:"name"`
+	Description string `xml:"description,omitempty"`
+	Coordinates string `xml:"Point>coordinates"`
+}
+
+// WriteKML writes businesses to w as KML placemarks, for Google Earth and
+// other map applications. Businesses without coordinates are left out.
+func WriteKML(w io.Writer, businesses []Business) error {
+	doc := kmlDocument{Xmlns: kmlNamespace}
+	for _, b := range businesses {
+		if !b.Coodinates.isSet() {
+			continue
+		}
+		doc.Placemarks = append(doc.Placemarks, kmlPlacemark{
+			ID:          b.ID,
+			Name:        b.Name,
+			Description: businessSummary(b),
+			Coordinates: FloatString(b.Coodinates.Longitude) + "," + FloatString(b.Coodinates.Latitude),
+		})
+	}
+	return writeXML(w, doc)
+}
+
+// businessSummary returns the address, rating and URL of the business on
+// separate lines.
+func businessSummary(b Business) string {
+	lines := []string{}
+	if len(b.Location.DisplayAddress) > 0 {
+		lines = append(lines, strings.Join(b.Location.DisplayAddress, ", "))
+	}
+	if b.Rating > 0 {
+		lines = append(lines, "Rating: "+FloatString(b.Rating)+" ("+IntString(b.ReviewCount)+" reviews)")
+	}
+	if b.URL != "" {
+		lines = append(lines, b.URL)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// writeXML writes v to w as an indented XML document.
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,119 @@
+package yelp
+
+import (
+	"context"
+	"strings"
+)
+
+// WithLocale sets the locale of the calls made without one, e.g. "en_US".
+func WithLocale(locale string) Option {
+	return func(c *client) {
+		c.locale = &locale
+	}
+}
+
+// WithLocaleFallback sets the locales to fall back to, in order, when a search
+// or business details call returns businesses with empty names or category
+// titles, e.g. WithLocaleFallback("de_DE", "en_US"). The locale actually used
+// is reported in the Locale field of the result.
+func WithLocaleFallback(locales ...string) Option {
+	return func(c *client) {
+		c.fallbackLocales = locales
+	}
+}
+
+// WithAcceptLanguage sets the Accept-Language header sent with every call to
+// value instead of deriving it from the locale of the call. An empty value
+// disables the header. WithHeaders overrides it for a single call.
+func WithAcceptLanguage(value string) Option {
+	return func(c *client) {
+		c.acceptLanguage = &value
+	}
+}
+
+// localeHeaders returns the Accept-Language header of a call made with
+// locale, e.g. "fr-CA" for "fr_CA", or nil when there is no locale or the
+// header is set in ctx.
+func (c *client) localeHeaders(ctx context.Context, locale *string) map[string]string {
+	if HeadersFromContext(ctx).Get("Accept-Language") != "" {
+		return nil
+	}
+	value := strings.ReplaceAll(StringVal(locale), "_", "-")
+	if c.acceptLanguage != nil {
+		value = *c.acceptLanguage
+	}
+	if value == "" {
+		return nil
+	}
+	return map[string]string{"Accept-Language": value}
+}
+
+// localeOr returns the requested locale, or the default locale of the client
+// when requested is nil.
+func (c *client) localeOr(requested *string) *string {
+	if requested == nil {
+		return c.locale
+	}
+	return requested
+}
+
+// localeChain returns the locales to try in order for a call made with the
+// requested locale, or nil when no fallback is configured.
+func (c *client) localeChain(requested *string) []string {
+	if len(c.fallbackLocales) == 0 {
+		return nil
+	}
+	if requested == nil {
+		return c.fallbackLocales
+	}
+
+	chain := []string{*requested}
+	for _, l := range c.fallbackLocales {
+		if l != *requested {
+			chain = append(chain, l)
+		}
+	}
+	return chain
+}
+
+// withLocales calls call with the requested locale and, while it reports the
+// result is not localized, with the next locale of the fallback chain.
+func (c *client) withLocales(requested *string, call func(locale *string) (localized bool, err error)) error {
+	chain := c.localeChain(requested)
+	if chain == nil {
+		_, err := call(requested)
+		return err
+	}
+
+	for i := range chain {
+		localized, err := call(&chain[i])
+		if err != nil || localized {
+			return err
+		}
+	}
+	return nil
+}
+
+// localized returns true when the business has a name and every category has
+// a title.
+func (b Business) localized() bool {
+	if b.Name == "" {
+		return false
+	}
+	for _, c := range b.Categories {
+		if c.Title == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// localized returns true when every business is localized.
+func (sr SearchResults) localized() bool {
+	for _, b := range sr.Businesses {
+		if !b.localized() {
+			return false
+		}
+	}
+	return true
+}
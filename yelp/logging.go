@@ -0,0 +1,22 @@
+package yelp
+
+import (
+	"context"
+	"log/slog"
+)
+
+// WithSlogLogger makes the client log to l: retries and exceeded quotas at
+// Info, cache hits and scheduler waits at Debug and background failures at
+// Warn. Nothing is logged without a logger.
+func WithSlogLogger(l *slog.Logger) Option {
+	return func(c *client) {
+		c.logger = l
+	}
+}
+
+// log logs msg at level when the client has a logger.
+func (c *client) log(ctx context.Context, level slog.Level, msg string, args ...any) {
+	if c.logger != nil {
+		c.logger.Log(ctx, level, msg, args...)
+	}
+}
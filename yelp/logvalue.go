@@ -0,0 +1,85 @@
+package yelp
+
+import (
+	"log/slog"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// bearerToken matches bearer tokens, such as an API key echoed in an error.
+var bearerToken = regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9._~+/=-]+`)
+
+// redact hides the bearer tokens in s.
+func redact(s string) string {
+	return bearerToken.ReplaceAllString(s, "${1}[REDACTED]")
+}
+
+// String returns the name, id, rating and review count of the business.
+func (b Business) String() string {
+	return b.Name + " (" + b.ID + ") " + FloatString(b.Rating) + "★ " + IntString(b.ReviewCount) + " reviews"
+}
+
+// LogValue returns the id, name, rating, review count and city of the
+// business as a slog group.
+func (b Business) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", b.ID),
+		slog.String("name", b.Name),
+		slog.Float64("rating", b.Rating),
+		slog.Int64("review_count", b.ReviewCount),
+		slog.String("city", b.Location.City),
+	)
+}
+
+// String returns the options as the query string of the search. Coordinates
+// are rounded to two decimals, about a kilometer, so logs do not hold the
+// precise position of users.
+func (so SearchOptions) String() string {
+	return so.logged().URLValues().Encode()
+}
+
+// LogValue returns the options set as a slog group, with coordinates rounded
+// as in String.
+func (so SearchOptions) LogValue() slog.Value {
+	vals := so.logged().URLValues()
+	keys := make([]string, 0, len(vals))
+	for key := range vals {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	attrs := make([]slog.Attr, len(keys))
+	for i, key := range keys {
+		attrs[i] = slog.String(key, strings.Join(vals[key], ","))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// logged returns a copy of so with its coordinates rounded.
+func (so SearchOptions) logged() SearchOptions {
+	if so.Coordinates != nil {
+		so.Coordinates = &Coordinates{
+			Latitude:  math.Round(so.Coordinates.Latitude*100) / 100,
+			Longitude: math.Round(so.Coordinates.Longitude*100) / 100,
+		}
+	}
+	return so
+}
+
+// String returns the same message as Error.
+func (e *APIError) String() string {
+	return e.Error()
+}
+
+// LogValue returns the status, code, description and request id of the error
+// as a slog group, with bearer tokens redacted.
+func (e *APIError) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int("status", e.StatusCode),
+		slog.String("code", e.Code),
+		slog.String("description", redact(e.Description)),
+		slog.String("request_id", e.RequestID),
+	)
+}
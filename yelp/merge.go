@@ -0,0 +1,129 @@
+package yelp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/ivancevich/go-yelp/match"
+)
+
+// MergeMethod is how a MergeResolution found the surviving business.
+type MergeMethod string
+
+// Available merge resolution methods.
+const (
+	// MergeUnchanged is a business still listed under its id.
+	MergeUnchanged MergeMethod = "unchanged"
+
+	// MergeRedirect is a business Yelp answers with another one.
+	MergeRedirect MergeMethod = "redirect"
+
+	// MergeMatch is a business found with the Business Match API after its
+	// id stopped resolving.
+	MergeMatch MergeMethod = "match"
+
+	// MergeUnresolved is a business no surviving listing was found for.
+	MergeUnresolved MergeMethod = "unresolved"
+)
+
+// MergeMinConfidence is the lowest confidence of a Business Match candidate
+// ResolveMerge accepts as the surviving business.
+const MergeMinConfidence = 0.7
+
+// MergeResolution is the outcome of ResolveMerge.
+type MergeResolution struct {
+	OldID  string
+	NewID  string
+	Method MergeMethod
+
+	// Confidence is how likely NewID is the same place, from 0 to 1.
+	Confidence float64
+
+	// Business is the surviving business, if any.
+	Business Business
+}
+
+// Resolved returns true when a surviving business was found.
+func (mr MergeResolution) Resolved() bool {
+	return mr.NewID != ""
+}
+
+// ResolveMerge finds the business that replaced oldID after Yelp merged or
+// deleted it. stored is the last known copy of the business; when oldID no
+// longer resolves, its name and location are looked up with the Business
+// Match API and the closest candidate is kept when it scores at least
+// MergeMinConfidence; the business is unresolved otherwise.
+func (c *client) ResolveMerge(ctx context.Context, oldID string, stored Business) (MergeResolution, error) {
+	mr := MergeResolution{OldID: oldID, Method: MergeUnresolved}
+
+	b, err := c.BusinessByID(ctx, oldID, BusinessOptions{})
+	var apiErr *APIError
+	switch {
+	case err == nil && (b.ID == oldID || b.Alias == oldID):
+		mr.NewID, mr.Method, mr.Confidence, mr.Business = b.ID, MergeUnchanged, 1, b
+		return mr, nil
+	case err == nil:
+		mr.NewID, mr.Method, mr.Confidence, mr.Business = b.ID, MergeRedirect, 1, b
+		return mr, nil
+	case !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound:
+		return mr, err
+	}
+
+	loc := stored.Location
+	mo := MatchOptions{
+		Name:     StringPtr(stored.Name),
+		Address1: StringPtr(loc.Address1),
+		City:     StringPtr(loc.City),
+		State:    StringPtr(loc.State),
+		Country:  StringPtr(loc.Country),
+	}
+	if loc.ZipCode != "" {
+		mo.ZipCode = StringPtr(loc.ZipCode)
+	}
+	if stored.Phone != "" {
+		mo.Phone = StringPtr(stored.Phone)
+	}
+	if stored.Coodinates.isSet() {
+		coords := stored.Coodinates
+		mo.Coordinates = &coords
+	}
+	if !mo.IsValid() || stored.Name == "" || loc.Address1 == "" {
+		return mr, nil
+	}
+
+	candidates, err := c.BusinessMatch(ctx, mo)
+	if err != nil {
+		return mr, err
+	}
+	for _, cand := range candidates {
+		if cand.ID == oldID {
+			continue
+		}
+		if score := sameBusinessScore(stored, cand); score >= MergeMinConfidence && score > mr.Confidence {
+			mr.NewID, mr.Method, mr.Confidence, mr.Business = cand.ID, MergeMatch, score, cand
+		}
+	}
+	return mr, nil
+}
+
+// sameBusinessScore returns how likely a and b are the same place, from 0 to
+// 1, see match.Score.
+func sameBusinessScore(a, b Business) float64 {
+	return match.Score(matchRecord(a), matchRecord(b))
+}
+
+// matchRecord returns b as a match.Record.
+func matchRecord(b Business) match.Record {
+	return match.Record{
+		Name: b.Name,
+		Address: match.Address{
+			Street:  b.Location.Address1,
+			City:    b.Location.City,
+			State:   b.Location.State,
+			ZipCode: b.Location.ZipCode,
+			Country: b.Location.Country,
+		},
+		Phone: b.Phone,
+	}
+}
@@ -0,0 +1,82 @@
+package yelp
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// notFoundSweep is the number of entries of a notFoundCache past which the
+// expired ones are dropped on insert.
+const notFoundSweep = 1024
+
+// WithNotFoundCache makes the client remember for ttl the requests Yelp
+// answered with a not found error, such as BUSINESS_NOT_FOUND, and return
+// the same error again without sending them, so repeated lookups of deleted
+// businesses do not use up the quota. A zero ttl disables it.
+func WithNotFoundCache(ttl time.Duration) Option {
+	return func(c *client) {
+		if ttl <= 0 {
+			c.notFound = nil
+			return
+		}
+		c.notFound = &notFoundCache{ttl: ttl, entries: map[string]notFoundEntry{}}
+	}
+}
+
+// notFoundCache remembers the not found errors by URL.
+type notFoundCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]notFoundEntry
+}
+
+// notFoundEntry is a remembered not found error.
+type notFoundEntry struct {
+	err       APIError
+	expiresAt time.Time
+}
+
+// get returns a copy of the error remembered for url, if not expired.
+func (nc *notFoundCache) get(url string, now time.Time) (*APIError, bool) {
+	nc.mu.Lock()
+	defer nc.mu.Unlock()
+	e, ok := nc.entries[url]
+	if !ok || now.After(e.expiresAt) {
+		return nil, false
+	}
+	err := e.err
+	return &err, true
+}
+
+// set remembers err for url.
+func (nc *notFoundCache) set(url string, err *APIError, now time.Time) {
+	nc.mu.Lock()
+	defer nc.mu.Unlock()
+	if len(nc.entries) >= notFoundSweep {
+		for k, e := range nc.entries {
+			if now.After(e.expiresAt) {
+				delete(nc.entries, k)
+			}
+		}
+	}
+	nc.entries[url] = notFoundEntry{err: *err, expiresAt: now.Add(nc.ttl)}
+}
+
+// notFoundDo returns the remembered not found error of the GET request of
+// url, or makes it with do and remembers its error when not found.
+func (c *client) notFoundDo(ctx context.Context, ep Endpoint, url string, do func() error) error {
+	if err, ok := c.notFound.get(url, c.clock.Now()); ok {
+		c.log(ctx, slog.LevelDebug, "yelp: cached not found", "endpoint", ep)
+		return err
+	}
+	err := do()
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.Class() == ErrorClassNotFound {
+		c.notFound.set(url, apiErr, c.clock.Now())
+	}
+	return err
+}
@@ -0,0 +1,121 @@
+package yelp
+
+import "context"
+
+// Page is a page of results of a listing endpoint.
+type Page[T any] struct {
+	Items []T
+
+	// Total is the number of results reported by the endpoint.
+	Total int64
+}
+
+// PageFunc fetches the page of limit results starting at offset.
+type PageFunc[T any] func(ctx context.Context, offset, limit int64) (Page[T], error)
+
+// Paginator iterates over the pages of a listing endpoint. It stops when a
+// page is short, when the total is reached or at the largest offset the
+// endpoint accepts.
+//
+//	p := yelp.SearchPages(client, so)
+//	for p.HasNext() {
+//		businesses, err := p.Next(ctx)
+//		...
+//	}
+type Paginator[T any] struct {
+	fetch  PageFunc[T]
+	offset int64
+	limit  int64
+	max    int64
+
+	total int64
+	done  bool
+}
+
+// NewPaginator returns a Paginator fetching pages of limit results with fetch,
+// from offset up to max, the largest offset plus limit the endpoint accepts.
+func NewPaginator[T any](offset, limit, max int64, fetch PageFunc[T]) *Paginator[T] {
+	return &Paginator[T]{
+		fetch:  fetch,
+		offset: offset,
+		limit:  limit,
+		max:    max,
+		total:  -1,
+		done:   limit <= 0 || offset >= max,
+	}
+}
+
+// HasNext returns true when there may be more pages.
+func (p *Paginator[T]) HasNext() bool {
+	return !p.done
+}
+
+// Total returns the number of results reported by the endpoint, or -1 before
+// the first page.
+func (p *Paginator[T]) Total() int64 {
+	return p.total
+}
+
+// Next fetches the next page. It returns nothing once HasNext is false. A
+// failed page can be fetched again by calling Next.
+func (p *Paginator[T]) Next(ctx context.Context) ([]T, error) {
+	if p.done {
+		return nil, nil
+	}
+	limit := p.limit
+	if p.offset+limit > p.max {
+		limit = p.max - p.offset
+	}
+
+	page, err := p.fetch(ctx, p.offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	p.total = page.Total
+	p.offset += int64(len(page.Items))
+	p.done = int64(len(page.Items)) < limit || p.offset >= p.total || p.offset >= p.max
+	return page.Items, nil
+}
+
+// All fetches the remaining pages and returns their results. The results of
+// the pages fetched before an error are returned with it.
+func (p *Paginator[T]) All(ctx context.Context) ([]T, error) {
+	all := []T{}
+	for p.HasNext() {
+		items, err := p.Next(ctx)
+		all = append(all, items...)
+		if err != nil {
+			return all, err
+		}
+	}
+	return all, nil
+}
+
+// SearchPages returns a Paginator over the results of the search so with c,
+// starting at so.Offset, in pages of so.Limit or 50 businesses.
+func SearchPages(c Client, so SearchOptions) *Paginator[Business] {
+	offset, limit := Int64Val(so.Offset), int64(maxSearchLimit)
+	if so.Limit != nil {
+		limit = *so.Limit
+	}
+	return NewPaginator(offset, limit, maxSearchWindow, func(ctx context.Context, offset, limit int64) (Page[Business], error) {
+		so.Offset, so.Limit = Int64Ptr(offset), Int64Ptr(limit)
+		sr, err := c.Search(ctx, so)
+		return Page[Business]{Items: sr.Businesses, Total: sr.Total}, err
+	})
+}
+
+// EventPages returns a Paginator over the results of the events search eo
+// with c, starting at eo.Offset, in pages of eo.Limit or the largest page of
+// events.
+func EventPages(c Client, eo EventsOptions) *Paginator[Event] {
+	offset, limit := Int64Val(eo.Offset), int64(maxEventsLimit)
+	if eo.Limit != nil {
+		limit = *eo.Limit
+	}
+	return NewPaginator(offset, limit, maxEventsWindow, func(ctx context.Context, offset, limit int64) (Page[Event], error) {
+		eo.Offset, eo.Limit = Int64Ptr(offset), Int64Ptr(limit)
+		er, err := c.SearchEvents(ctx, eo)
+		return Page[Event]{Items: er.Events, Total: er.Total}, err
+	})
+}
@@ -0,0 +1,83 @@
+package yelp
+
+import (
+	"errors"
+	"strings"
+)
+
+// nanpCountryCode is the country code of the North American Numbering Plan.
+const nanpCountryCode = "1"
+
+// ErrInvalidPhone is returned when a phone number cannot be converted to E.164.
+var ErrInvalidPhone = errors.New("phone number is not a valid E.164 number")
+
+// NormalizePhone converts common phone formats such as "(415) 555-1234",
+// "415.555.1234" or "+44 20 7946 0018" to the E.164 format the Yelp API
+// requires. Numbers without a leading "+" are assumed to be North American.
+func NormalizePhone(phone string) (string, error) {
+	phone = strings.TrimSpace(phone)
+	international := strings.HasPrefix(phone, "+") || strings.HasPrefix(phone, "00")
+
+	digits := make([]byte, 0, len(phone))
+	for i := 0; i < len(phone); i++ {
+		if phone[i] >= '0' && phone[i] <= '9' {
+			digits = append(digits, phone[i])
+		}
+	}
+	num := string(digits)
+	if strings.HasPrefix(phone, "00") {
+		num = num[2:]
+	}
+
+	if !international {
+		switch {
+		case len(num) == 10:
+			num = nanpCountryCode + num
+		case len(num) == 11 && strings.HasPrefix(num, nanpCountryCode):
+		default:
+			return "", ErrInvalidPhone
+		}
+	}
+
+	// E.164 numbers have at most 15 digits and country codes never start with 0.
+	if len(num) < 8 || len(num) > 15 || num[0] == '0' {
+		return "", ErrInvalidPhone
+	}
+	return "+" + num, nil
+}
+
+// PhoneE164 returns the phone of the business in E.164 format or an empty
+// string when the business has no valid phone.
+func (b Business) PhoneE164() string {
+	phone, err := NormalizePhone(b.Phone)
+	if err != nil {
+		return ""
+	}
+	return phone
+}
+
+// DisplayPhoneFormatted returns the phone of the business formatted for the
+// given locale, e.g. "en_US". North American numbers are shown in national
+// format to North American locales and in international format otherwise.
+// Other numbers fall back to DisplayPhone.
+func (b Business) DisplayPhoneFormatted(locale string) string {
+	phone := b.PhoneE164()
+	if phone == "" || !strings.HasPrefix(phone, "+"+nanpCountryCode) || len(phone) != 12 {
+		return b.DisplayPhone
+	}
+
+	area, exchange, line := phone[2:5], phone[5:8], phone[8:]
+	switch localeCountry(locale) {
+	case "US", "CA":
+		return "(" + area + ") " + exchange + "-" + line
+	}
+	return "+1 " + area + "-" + exchange + "-" + line
+}
+
+// localeCountry returns the country part of a locale such as "en_US".
+func localeCountry(locale string) string {
+	if i := strings.LastIndexAny(locale, "_-"); i >= 0 {
+		return strings.ToUpper(locale[i+1:])
+	}
+	return ""
+}
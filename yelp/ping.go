@@ -0,0 +1,72 @@
+package yelp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// pingPath is a cheap request to check the API is reachable
+const pingPath = "/categories/hotdogs"
+
+// RateLimit is the state of the daily rate limit reported by Yelp.
+type RateLimit struct {
+	DailyLimit int
+	Remaining  int
+	ResetTime  time.Time
+}
+
+// PingResult describes the state of the API seen by a client.
+type PingResult struct {
+	Reachable  bool
+	Authorized bool
+	StatusCode int
+	Latency    time.Duration
+	RateLimit  RateLimit
+	RequestID  string
+}
+
+// Ping makes a cheap request and reports whether the API is reachable, the
+// API key is accepted and how many requests are left today, for readiness
+// probes. The request bypasses the cache and the not found cache, so it
+// always reaches Yelp. The error is the one of the request when it failed.
+func (c *client) Ping(ctx context.Context) (PingResult, error) {
+	pr := PingResult{}
+	done, err := c.track()
+	if err != nil {
+		return pr, err
+	}
+	defer done()
+	ctx, cancel := c.withShutdown(ctx)
+	defer cancel()
+
+	started := c.clock.Now()
+	resp, err := c.fetch(ctx, EndpointCategories, "GET", c.url(pingPath), nil, withContextHeaders(ctx, nil), &json.RawMessage{})
+	pr.Latency = c.clock.Now().Sub(started)
+
+	// Yelp answered when the request succeeded or failed with an APIError
+	// or an undecodable body.
+	var apiErr *APIError
+	class := ClassifyError(err)
+	pr.Reachable = err == nil || errors.As(err, &apiErr) || class == ErrorClassDecode
+	pr.Authorized = pr.Reachable && class != ErrorClassUnauthorized
+	if resp != nil {
+		pr.StatusCode = resp.StatusCode
+		pr.RateLimit = rateLimitFromHeader(resp.Header)
+		pr.RequestID = requestID(resp.Header)
+	}
+	return pr, err
+}
+
+// rateLimitFromHeader returns the RateLimit reported in the headers of a
+// response. Missing headers leave fields zero.
+func rateLimitFromHeader(h http.Header) RateLimit {
+	rl := RateLimit{}
+	rl.DailyLimit, _ = strconv.Atoi(h.Get("RateLimit-DailyLimit"))
+	rl.Remaining, _ = strconv.Atoi(h.Get("RateLimit-Remaining"))
+	rl.ResetTime, _ = time.Parse(time.RFC3339, h.Get("RateLimit-ResetTime"))
+	return rl
+}
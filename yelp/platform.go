@@ -0,0 +1,38 @@
+package yelp
+
+import "net/url"
+
+// DevicePlatform determines the kind of URLs Yelp returns for businesses.
+type DevicePlatform string
+
+// Available device platforms.
+const (
+	DevicePlatformAndroid       DevicePlatform = "android"
+	DevicePlatformIOS           DevicePlatform = "ios"
+	DevicePlatformMobileGeneric DevicePlatform = "mobile-generic"
+)
+
+const (
+	// appBusinessURL is the Yelp app deep link to a business
+	appBusinessURL = "yelp:///biz/"
+
+	// mobileBusinessURL is the Yelp mobile site URL to a business
+	mobileBusinessURL = "https://m.yelp.com/biz/"
+)
+
+// DeepLinkURL returns the URL that opens the business on the given platform.
+// Android and iOS get a Yelp app deep link, any other platform falls back to
+// the URL returned by the API.
+func (b Business) DeepLinkURL(platform DevicePlatform) string {
+	id := b.Alias
+	if id == "" {
+		id = b.ID
+	}
+	switch platform {
+	case DevicePlatformAndroid, DevicePlatformIOS:
+		return appBusinessURL + url.PathEscape(id)
+	case DevicePlatformMobileGeneric:
+		return mobileBusinessURL + url.PathEscape(id)
+	}
+	return b.URL
+}
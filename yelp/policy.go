@@ -0,0 +1,95 @@
+package yelp
+
+import "time"
+
+// Endpoint identifies a family of Yelp API endpoints.
+type Endpoint string
+
+// Available endpoint families.
+const (
+	EndpointSearch     Endpoint = "search"
+	EndpointBusiness   Endpoint = "business"
+	EndpointReviews    Endpoint = "reviews"
+	EndpointEvents     Endpoint = "events"
+	EndpointCategories Endpoint = "categories"
+)
+
+const (
+	// defaultBackoff is the wait before the first retry when a Policy has
+	// none.
+	defaultBackoff = 500 * time.Millisecond
+
+	// maxBackoff is the longest wait before a retry.
+	maxBackoff = time.Minute
+
+	// maxBackoffShift is the largest number of doublings of the backoff.
+	maxBackoffShift = 30
+)
+
+// Policy defines how requests to an endpoint family are made.
+type Policy struct {
+	// Timeout is the budget for a whole call, retries included. Zero means no
+	// timeout besides the one of the http.Client.
+	Timeout time.Duration
+
+	// MaxRetries is the number of times a call is retried after an error of
+	// a retryable class, such as a network error, a 429 or a 5xx response.
+	MaxRetries int
+
+	// Backoff is the wait before the first retry, doubled on each retry up
+	// to a minute.
+	Backoff time.Duration
+}
+
+// WithPolicy sets the Policy used for endpoint families without their own.
+func WithPolicy(p Policy) Option {
+	return func(c *client) {
+		c.defaultPolicy = p
+	}
+}
+
+// WithPolicies sets the Policy of each endpoint family in policies.
+func WithPolicies(policies map[Endpoint]Policy) Option {
+	return func(c *client) {
+		if c.policies == nil {
+			c.policies = map[Endpoint]Policy{}
+		}
+		for ep, p := range policies {
+			c.policies[ep] = p
+		}
+	}
+}
+
+// policy returns the Policy of the endpoint family.
+func (c *client) policy(ep Endpoint) Policy {
+	if p, ok := c.policies[ep]; ok {
+		return p
+	}
+	return c.defaultPolicy
+}
+
+// backoff returns the wait before the given retry, starting at 1, capped at
+// maxBackoff.
+func (p Policy) backoff(retry int) time.Duration {
+	d := p.Backoff
+	if d <= 0 {
+		d = defaultBackoff
+	}
+	shift := retry - 1
+	if shift < 0 {
+		shift = 0
+	}
+	if shift > maxBackoffShift {
+		shift = maxBackoffShift
+	}
+	if d > maxBackoff>>shift {
+		return maxBackoff
+	}
+	return d << shift
+}
+
+// shouldRetry returns true when a request that failed with err may succeed
+// when sent again, see ClassifyError.
+func shouldRetry(err error) bool {
+	return ClassifyError(err).Retryable()
+}
@@ -0,0 +1,28 @@
+package yelp
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPolicyBackoff(t *testing.T) {
+	tests := []struct {
+		policy Policy
+		retry  int
+		want   time.Duration
+	}{
+		{Policy{}, 1, defaultBackoff},
+		{Policy{}, 2, 2 * defaultBackoff},
+		{Policy{Backoff: time.Second}, 3, 4 * time.Second},
+		{Policy{Backoff: time.Second}, 7, maxBackoff},
+		{Policy{Backoff: time.Second}, 64, maxBackoff},
+		{Policy{}, 64, maxBackoff},
+		{Policy{Backoff: time.Hour}, 1, maxBackoff},
+		{Policy{Backoff: time.Hour}, 64, maxBackoff},
+	}
+	for _, tt := range tests {
+		if got := tt.policy.backoff(tt.retry); got != tt.want {
+			t.Errorf("Policy{Backoff: %v}.backoff(%d) = %v, want %v", tt.policy.Backoff, tt.retry, got, tt.want)
+		}
+	}
+}
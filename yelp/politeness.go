@@ -0,0 +1,72 @@
+package yelp
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// politeness spaces out requests with a token bucket.
+type politeness struct {
+	mu     sync.Mutex
+	delay  time.Duration
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// WithPoliteness makes the client send at most one request every minDelay on
+// average, allowing bursts of up to burst requests after a quiet period, to
+// stay well below the rate limits and avoid storms of 429 responses. Retries
+// are spaced out too. A burst below 1 is 1.
+func WithPoliteness(minDelay time.Duration, burst int) Option {
+	return func(c *client) {
+		if burst < 1 {
+			burst = 1
+		}
+		c.politeness = &politeness{delay: minDelay, burst: float64(burst), tokens: float64(burst)}
+	}
+}
+
+// reserve takes a token at now and returns how long to wait before sending.
+func (p *politeness) reserve(now time.Time) time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.delay <= 0 {
+		return 0
+	}
+	if !p.last.IsZero() {
+		p.tokens += float64(now.Sub(p.last)) / float64(p.delay)
+		if p.tokens > p.burst {
+			p.tokens = p.burst
+		}
+	}
+	p.last = now
+	p.tokens--
+	if p.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-p.tokens * float64(p.delay))
+}
+
+// waitPolitely waits for the turn of a request when politeness is enabled.
+func (c *client) waitPolitely(ctx context.Context, ep Endpoint) error {
+	if c.politeness == nil {
+		return nil
+	}
+	wait := c.politeness.reserve(c.clock.Now())
+	if wait <= 0 {
+		return nil
+	}
+
+	c.log(ctx, slog.LevelDebug, "yelp: politeness delay", "endpoint", ep, "wait", wait)
+	timer := c.clock.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
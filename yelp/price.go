@@ -0,0 +1,62 @@
+package yelp
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// currencies are the currencies of the symbols Yelp uses for price levels.
+var currencies = map[string]string{"$": "USD", "€": "EUR", "£": "GBP", "¥": "JPY"}
+
+// Price is the price level of a business, parsed from the repeated currency
+// symbol Yelp returns, e.g. "€€". Prices of different markets compare by
+// Level.
+type Price struct {
+	// Level is the price level from 1, inexpensive, to 4, or 0 when unknown.
+	Level int
+	// Symbol is the currency symbol, e.g. "$" or "¥".
+	Symbol string
+}
+
+// ParsePrice parses a price such as "$$" or "¥¥¥". ok is false when s is not
+// one symbol repeated 1 to 4 times.
+func ParsePrice(s string) (p Price, ok bool) {
+	s = strings.TrimSpace(s)
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError || size == 0 {
+		return Price{}, false
+	}
+	symbol := s[:size]
+	level := utf8.RuneCountInString(s)
+	if level > 4 || strings.Repeat(symbol, level) != s {
+		return Price{}, false
+	}
+	return Price{Level: level, Symbol: symbol}, true
+}
+
+// Currency returns the ISO 4217 code of the currency of the symbol, e.g.
+// "EUR" for "€", or an empty string when unknown.
+func (p Price) Currency() string {
+	return currencies[p.Symbol]
+}
+
+// String returns the symbol repeated Level times, as returned by Yelp.
+func (p Price) String() string {
+	return strings.Repeat(p.Symbol, p.Level)
+}
+
+// PriceLevel returns the parsed price of the business, with a zero Level
+// when it has none or it cannot be parsed.
+func (b Business) PriceLevel() Price {
+	p, _ := ParsePrice(b.Price)
+	return p
+}
+
+// PriceAtMost returns a BusinessFilter keeping the businesses with a known
+// price level of at most level, in any currency.
+func PriceAtMost(level int) BusinessFilter {
+	return func(b Business) bool {
+		p := b.PriceLevel()
+		return p.Level > 0 && p.Level <= level
+	}
+}
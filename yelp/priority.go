@@ -0,0 +1,48 @@
+package yelp
+
+import "context"
+
+// Priority is the class of a request, telling which requests are sent first
+// when a Scheduler queues them or the quota runs low.
+type Priority int
+
+// Available priorities.
+const (
+	// PriorityInteractive is for requests a user waits for. It is the
+	// priority of requests without one.
+	PriorityInteractive Priority = iota
+	// PriorityBatch is for background work that can wait.
+	PriorityBatch
+)
+
+// numPriorities is the number of priorities.
+const numPriorities = 2
+
+// String returns the name of the priority.
+func (p Priority) String() string {
+	if p == PriorityBatch {
+		return "batch"
+	}
+	return "interactive"
+}
+
+// priorityKey is the context key of the priority.
+type priorityKey struct{}
+
+// WithPriority returns a copy of ctx tagging its requests with p. A Scheduler
+// sends the queued interactive requests before the batch ones, and a
+// QuotaManager can keep part of the quota for interactive requests, see
+// QuotaManager.SetInteractiveReserve.
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// PriorityFromContext returns the priority of ctx, PriorityInteractive when
+// it has none.
+func PriorityFromContext(ctx context.Context) Priority {
+	p, ok := ctx.Value(priorityKey{}).(Priority)
+	if !ok || p < 0 || p >= numPriorities {
+		return PriorityInteractive
+	}
+	return p
+}
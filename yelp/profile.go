@@ -0,0 +1,66 @@
+package yelp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultProfile is the profile whose configuration is DefaultConfigPath.
+const DefaultProfile = "default"
+
+// EnvProfile names the profile used by LoadProfile when given none.
+const EnvProfile = "YELP_PROFILE"
+
+// ProfileConfigPath returns the path of the configuration file of the named
+// profile: DefaultConfigPath for DefaultProfile, profiles/<name>.yaml next to
+// it otherwise.
+func ProfileConfigPath(name string) (string, error) {
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("yelp: invalid profile name %q", name)
+	}
+	path, err := DefaultConfigPath()
+	if err != nil || name == DefaultProfile {
+		return path, err
+	}
+	return filepath.Join(filepath.Dir(path), "profiles", name+".yaml"), nil
+}
+
+// LoadProfile reads the Config of the named profile, or of the profile named
+// by YELP_PROFILE when name is empty, falling back to DefaultProfile.
+func LoadProfile(name string) (Config, error) {
+	if name == "" {
+		name = os.Getenv(EnvProfile)
+	}
+	if name == "" {
+		name = DefaultProfile
+	}
+	path, err := ProfileConfigPath(name)
+	if err != nil {
+		return Config{}, err
+	}
+	return LoadConfig(path)
+}
+
+// Profiles returns the names of the configured profiles, sorted.
+func Profiles() ([]string, error) {
+	path, err := DefaultConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	if _, err := os.Stat(path); err == nil {
+		names = append(names, DefaultProfile)
+	}
+	files, err := filepath.Glob(filepath.Join(filepath.Dir(path), "profiles", "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		names = append(names, strings.TrimSuffix(filepath.Base(f), ".yaml"))
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,97 @@
+package yelp
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Progress is the state of a batch operation, such as TileSearch,
+// BusinessesByIDs or BulkMatch.
+type Progress struct {
+	// Done is the number of steps done, Total the number known so far. Total
+	// can grow, as when a TileSearch splits a tile.
+	Done  int
+	Total int
+
+	// Errors is the number of steps that failed.
+	Errors int
+
+	// Elapsed is the time since the operation started, Rate the number of
+	// steps done per second and ETA the estimated time left, zero until a
+	// step is done.
+	Elapsed time.Duration
+	Rate    float64
+	ETA     time.Duration
+}
+
+// ProgressFunc receives the progress of a batch operation after each step.
+// It is called from the goroutines of the operation and must not block.
+type ProgressFunc func(Progress)
+
+// progressKey is the context key of the ProgressFunc.
+type progressKey struct{}
+
+// WithProgress returns a copy of ctx making the batch operations called with
+// it report their progress to fn, e.g. to draw a progress bar or export
+// metrics.
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// ProgressTracker counts the steps of a batch operation and reports its
+// Progress to a ProgressFunc. A nil *ProgressTracker does nothing.
+type ProgressTracker struct {
+	mu      sync.Mutex
+	fn      ProgressFunc
+	started time.Time
+	p       Progress
+}
+
+// NewProgressTracker returns a ProgressTracker reporting to fn, or nil when
+// fn is nil.
+func NewProgressTracker(fn ProgressFunc) *ProgressTracker {
+	if fn == nil {
+		return nil
+	}
+	return &ProgressTracker{fn: fn, started: time.Now()}
+}
+
+// progressFromContext returns a ProgressTracker reporting to the ProgressFunc
+// of ctx, or nil when it has none.
+func progressFromContext(ctx context.Context) *ProgressTracker {
+	fn, _ := ctx.Value(progressKey{}).(ProgressFunc)
+	return NewProgressTracker(fn)
+}
+
+// Add adds n steps to the total.
+func (pt *ProgressTracker) Add(n int) {
+	if pt == nil {
+		return
+	}
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+	pt.p.Total += n
+}
+
+// Step counts a step done, failed when err is not nil, and reports the
+// progress.
+func (pt *ProgressTracker) Step(err error) {
+	if pt == nil {
+		return
+	}
+	pt.mu.Lock()
+	pt.p.Done++
+	if err != nil {
+		pt.p.Errors++
+	}
+	pt.p.Elapsed = time.Since(pt.started)
+	if secs := pt.p.Elapsed.Seconds(); secs > 0 {
+		pt.p.Rate = float64(pt.p.Done) / secs
+		pt.p.ETA = time.Duration(float64(pt.p.Total-pt.p.Done) / pt.p.Rate * float64(time.Second))
+	}
+	p := pt.p
+	pt.mu.Unlock()
+
+	pt.fn(p)
+}
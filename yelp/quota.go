@@ -0,0 +1,262 @@
+package yelp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrQuotaExceeded is returned when a request is rejected because its tenant
+// used up its daily quota.
+var ErrQuotaExceeded = errors.New("daily quota exceeded")
+
+// QuotaStore keeps the number of requests sent by each tenant. Keeping it in
+// a store shared by several processes, e.g. DynamoDB or Redis, lets
+// short-lived processes such as serverless functions enforce the caps of a
+// QuotaManager between them.
+type QuotaStore interface {
+	// Increment adds one to the counter of key and returns its new value. The
+	// counter can be dropped once expiresAt is past.
+	Increment(ctx context.Context, key string, expiresAt time.Time) (int64, error)
+	// Count returns the value of the counter of key, zero when it has none.
+	Count(ctx context.Context, key string) (int64, error)
+	// Decrement subtracts one from the counter of key, to refund a rejected
+	// request. A counter that was dropped is left alone.
+	Decrement(ctx context.Context, key string) error
+}
+
+// QuotaManager caps the number of requests each tenant can send per day, so
+// background jobs cannot use up the daily limit of an API key shared with
+// interactive traffic. Days start at midnight UTC. Tenants without a
+// registered cap are not limited.
+type QuotaManager struct {
+	mu       sync.Mutex
+	caps     map[string]int
+	keyLimit int
+	reserve  int
+	store    QuotaStore
+	clock    Clock
+}
+
+// NewQuotaManager returns a QuotaManager with the daily cap of each tenant in
+// caps, e.g. {"crawler": 3000, "web": 1500}, counting the requests in memory.
+func NewQuotaManager(caps map[string]int) *QuotaManager {
+	return NewQuotaManagerWithStore(caps, &memoryQuotaStore{})
+}
+
+// NewQuotaManagerWithStore returns a QuotaManager with the daily cap of each
+// tenant in caps counting the requests in store.
+func NewQuotaManagerWithStore(caps map[string]int, store QuotaStore) *QuotaManager {
+	qm := &QuotaManager{
+		caps:  map[string]int{},
+		store: store,
+		clock: systemClock{},
+	}
+	for tenant, limit := range caps {
+		qm.caps[tenant] = limit
+	}
+	return qm
+}
+
+// WithQuotaManager makes the client charge every request it sends to the
+// tenant of its context and reject it with ErrQuotaExceeded when the tenant
+// has no quota left. Retries are charged too, as Yelp counts them.
+func WithQuotaManager(qm *QuotaManager) Option {
+	return func(c *client) {
+		c.quota = qm
+	}
+}
+
+// SetClock makes RemainingContext tell the day with clk instead of the system
+// clock. Requests are charged on the day of the Clock of the client sending
+// them, see WithClock.
+func (qm *QuotaManager) SetClock(clk Clock) {
+	qm.mu.Lock()
+	defer qm.mu.Unlock()
+	qm.clock = clk
+}
+
+// Register sets the daily cap of tenant.
+func (qm *QuotaManager) Register(tenant string, dailyCap int) {
+	qm.mu.Lock()
+	defer qm.mu.Unlock()
+	qm.caps[tenant] = dailyCap
+}
+
+// SetDailyLimit caps the number of requests sent per day by all the tenants
+// together, e.g. to the daily limit of the API key. Replicas sharing the key
+// coordinate through a shared QuotaStore. Zero removes the cap.
+func (qm *QuotaManager) SetDailyLimit(limit int) {
+	qm.mu.Lock()
+	defer qm.mu.Unlock()
+	qm.keyLimit = limit
+}
+
+// SetInteractiveReserve keeps the last n requests of every cap for
+// interactive requests: batch requests, see WithPriority, are rejected with
+// ErrQuotaExceeded once fewer than n requests are left.
+func (qm *QuotaManager) SetInteractiveReserve(n int) {
+	qm.mu.Lock()
+	defer qm.mu.Unlock()
+	qm.reserve = n
+}
+
+// Remaining returns the number of requests tenant can still send today, or -1
+// when tenant has no cap. It returns 0 when the store cannot be read, see
+// RemainingContext.
+func (qm *QuotaManager) Remaining(tenant string) int {
+	left, err := qm.RemainingContext(context.Background(), tenant)
+	if err != nil {
+		return 0
+	}
+	return left
+}
+
+// RemainingContext is like Remaining but returns the error of the store.
+func (qm *QuotaManager) RemainingContext(ctx context.Context, tenant string) (int, error) {
+	limit, ok := qm.cap(tenant)
+	if !ok {
+		return -1, nil
+	}
+	qm.mu.Lock()
+	now := qm.clock.Now()
+	qm.mu.Unlock()
+	key, _ := quotaKey(tenant, now)
+	used, err := qm.store.Count(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	if left := int64(limit) - used; left > 0 {
+		return int(left), nil
+	}
+	return 0, nil
+}
+
+// charge counts a request sent at now for the tenant of ctx, returning ErrQuotaExceeded
+// when it or the API key has no quota left for the priority of ctx. The
+// tenant cap is checked first, so the requests of a tenant over its cap are
+// not counted against the API key. Counters are incremented before being
+// checked, so a store shared between processes never lets more requests
+// through than the cap, and rejected requests are refunded, so batch retries
+// do not use up the interactive reserve.
+func (qm *QuotaManager) charge(ctx context.Context, now time.Time) error {
+	qm.mu.Lock()
+	keyLimit, reserve := qm.keyLimit, qm.reserve
+	qm.mu.Unlock()
+	if PriorityFromContext(ctx) != PriorityBatch {
+		reserve = 0
+	}
+
+	tenant := TenantFromContext(ctx)
+	if limit, ok := qm.cap(tenant); ok {
+		key, expiresAt := quotaKey(tenant, now)
+		if err := qm.increment(ctx, key, expiresAt, limit-reserve); err != nil {
+			return err
+		}
+		if keyLimit > 0 {
+			dayKey, _ := quotaDayKey(now)
+			if err := qm.increment(ctx, dayKey, expiresAt, keyLimit-reserve); err != nil {
+				if derr := qm.store.Decrement(ctx, key); derr != nil {
+					return derr
+				}
+				return err
+			}
+		}
+		return nil
+	}
+	if keyLimit > 0 {
+		key, expiresAt := quotaDayKey(now)
+		return qm.increment(ctx, key, expiresAt, keyLimit-reserve)
+	}
+	return nil
+}
+
+// increment adds one to the counter of key, returning ErrQuotaExceeded and
+// taking it back when it goes past limit.
+func (qm *QuotaManager) increment(ctx context.Context, key string, expiresAt time.Time, limit int) error {
+	used, err := qm.store.Increment(ctx, key, expiresAt)
+	if err != nil {
+		return err
+	}
+	if used > int64(limit) {
+		if err := qm.store.Decrement(ctx, key); err != nil {
+			return err
+		}
+		return ErrQuotaExceeded
+	}
+	return nil
+}
+
+// cap returns the daily cap of tenant.
+func (qm *QuotaManager) cap(tenant string) (int, bool) {
+	qm.mu.Lock()
+	defer qm.mu.Unlock()
+	limit, ok := qm.caps[tenant]
+	return limit, ok
+}
+
+// quotaDayKey returns the store key counting all the requests of the UTC day
+// of now and the time the day ends.
+func quotaDayKey(now time.Time) (string, time.Time) {
+	day := now.UTC().Truncate(24 * time.Hour)
+	return "yelp:quota:" + day.Format("2006-01-02"), day.Add(24 * time.Hour)
+}
+
+// quotaKey returns the store key counting the requests of tenant on the UTC
+// day of now and the time the day ends.
+func quotaKey(tenant string, now time.Time) (string, time.Time) {
+	key, expiresAt := quotaDayKey(now)
+	return key + ":" + tenant, expiresAt
+}
+
+// memoryQuotaStore is a QuotaStore keeping the counters in memory.
+type memoryQuotaStore struct {
+	mu       sync.Mutex
+	counters map[string]quotaCounter
+}
+
+// quotaCounter is a counter of a memoryQuotaStore.
+type quotaCounter struct {
+	n         int64
+	expiresAt time.Time
+}
+
+// Increment adds one to the counter of key, dropping the counters of the
+// previous days, which expire before the day of expiresAt starts.
+func (s *memoryQuotaStore) Increment(_ context.Context, key string, expiresAt time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.counters == nil {
+		s.counters = map[string]quotaCounter{}
+	}
+	dayStart := expiresAt.Add(-24 * time.Hour)
+	for k, qc := range s.counters {
+		if !qc.expiresAt.After(dayStart) {
+			delete(s.counters, k)
+		}
+	}
+	qc := s.counters[key]
+	qc.n++
+	qc.expiresAt = expiresAt
+	s.counters[key] = qc
+	return qc.n, nil
+}
+
+// Decrement subtracts one from the counter of key, if any.
+func (s *memoryQuotaStore) Decrement(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if qc, ok := s.counters[key]; ok && qc.n > 0 {
+		qc.n--
+		s.counters[key] = qc
+	}
+	return nil
+}
+
+// Count returns the value of the counter of key.
+func (s *memoryQuotaStore) Count(_ context.Context, key string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.counters[key].n, nil
+}
@@ -0,0 +1,93 @@
+package yelp
+
+import (
+	"math"
+	"sort"
+)
+
+// Ranker reorders businesses on the client by a weighted score of their
+// rating, review count and distance.
+type Ranker struct {
+	RatingWeight   float64
+	ReviewsWeight  float64
+	DistanceWeight float64
+}
+
+// DefaultRanker favors the rating, then the number of reviews and the
+// distance.
+var DefaultRanker = Ranker{
+	RatingWeight:   0.6,
+	ReviewsWeight:  0.25,
+	DistanceWeight: 0.15,
+}
+
+// RankedBusiness is a business with its score and how it was computed.
+type RankedBusiness struct {
+	Business
+	Score       float64
+	Explanation Explanation
+}
+
+// Explanation describes how the score of a business was computed: the score
+// is the sum of the contributions of the components.
+type Explanation struct {
+	Components []ScoreComponent
+}
+
+// ScoreComponent is a part of the score of a business. Value is the
+// component normalized between 0 and 1 and Contribution its share of the
+// score, Value times Weight over the sum of the weights.
+type ScoreComponent struct {
+	Name         string
+	Value        float64
+	Weight       float64
+	Contribution float64
+}
+
+// Rerank returns the businesses ordered by score, highest first. Review counts
+// and distances are normalized against the largest ones in businesses.
+func (r Ranker) Rerank(businesses []Business) []RankedBusiness {
+	var maxReviews int64
+	var maxDistance float64
+	for _, b := range businesses {
+		if b.ReviewCount > maxReviews {
+			maxReviews = b.ReviewCount
+		}
+		if b.Distance > maxDistance {
+			maxDistance = b.Distance
+		}
+	}
+
+	total := r.RatingWeight + r.ReviewsWeight + r.DistanceWeight
+	ranked := make([]RankedBusiness, len(businesses))
+	for i, b := range businesses {
+		components := []ScoreComponent{
+			{Name: "rating", Value: b.Rating / 5, Weight: r.RatingWeight},
+			{Name: "reviews", Value: ratio(math.Log1p(float64(b.ReviewCount)), math.Log1p(float64(maxReviews))), Weight: r.ReviewsWeight},
+			{Name: "distance", Value: 1 - ratio(b.Distance, maxDistance), Weight: r.DistanceWeight},
+		}
+
+		rb := RankedBusiness{Business: b}
+		for j := range components {
+			if total > 0 {
+				components[j].Contribution = components[j].Value * components[j].Weight / total
+			}
+			rb.Score += components[j].Contribution
+		}
+		rb.Explanation = Explanation{Components: components}
+		ranked[i] = rb
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+	return ranked
+}
+
+// ratio returns a over b, or 0 when b is 0.
+func ratio(a, b float64) float64 {
+	if b == 0 {
+		return 0
+	}
+	return a / b
+}
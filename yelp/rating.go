@@ -0,0 +1,52 @@
+package yelp
+
+import "math"
+
+// RatingImageSize is the size of the star rating images of Yelp.
+type RatingImageSize string
+
+// Available star rating image sizes.
+const (
+	RatingImageSmall   RatingImageSize = "small"
+	RatingImageRegular RatingImageSize = "regular"
+	RatingImageLarge   RatingImageSize = "large"
+)
+
+// RatingImageBaseURL is where the star rating images are served from. It can
+// be changed to serve the images of the Yelp brand kit from another host,
+// keeping their stars_[size_]N[_half].png names.
+var RatingImageBaseURL = "https://s3-media1.fl.yelpcdn.com/assets/2/www/img/f1def11e4e79/ico/stars/v1/"
+
+// RatingImageURL returns the URL of the Yelp star image for rating, rounded to
+// the nearest half star, in the given size, as Yelp's display requirements
+// ask to show ratings with their star images.
+func RatingImageURL(rating float64, size RatingImageSize) string {
+	halves := ratingHalfStars(rating)
+	name := "stars_"
+	if size == RatingImageSmall || size == RatingImageLarge {
+		name += string(size) + "_"
+	}
+	name += IntString(int64(halves / 2))
+	if halves%2 == 1 {
+		name += "_half"
+	}
+	return RatingImageBaseURL + name + ".png"
+}
+
+// RatingHalfStars returns the rating of the business as a number of half
+// stars, from 0 to 10.
+func (b Business) RatingHalfStars() int {
+	return ratingHalfStars(b.Rating)
+}
+
+// RatingImageURL returns the URL of the star image of the rating of the
+// business, see RatingImageURL.
+func (b Business) RatingImageURL(size RatingImageSize) string {
+	return RatingImageURL(b.Rating, size)
+}
+
+// ratingHalfStars returns rating rounded to the nearest half star as a number
+// of half stars, from 0 to 10.
+func ratingHalfStars(rating float64) int {
+	return int(math.Round(math.Max(0, math.Min(5, rating)) * 2))
+}
@@ -0,0 +1,134 @@
+package yelp
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Store persists the businesses and reviews mirrored by a Refresher.
+type Store interface {
+	UpsertBusinesses(ctx context.Context, businesses []Business) error
+	UpsertReviews(ctx context.Context, businessID string, reviews []Review) error
+}
+
+// Refresher mirrors a set of businesses: it fetches their details and reviews
+// at a fixed interval and upserts them into a Store. New reviews, rating
+// changes and closures are published when a Publisher is set.
+type Refresher struct {
+	client    Client
+	store     Store
+	publisher Publisher
+	interval  time.Duration
+
+	mu    sync.Mutex
+	ids   []string
+	state map[string]*mirrorState
+}
+
+// NewRefresher returns a Refresher fetching the tracked businesses with c
+// every interval and upserting them into s, if not nil.
+func NewRefresher(c Client, s Store, interval time.Duration) *Refresher {
+	return &Refresher{
+		client:   c,
+		store:    s,
+		interval: interval,
+		state:    map[string]*mirrorState{},
+	}
+}
+
+// PublishTo makes the Refresher publish the changes it detects to p. Changes
+// are detected from the second refresh of a business on.
+func (r *Refresher) PublishTo(p Publisher) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.publisher = p
+}
+
+// Track adds businesses to mirror by their ids.
+func (r *Refresher) Track(ids ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, id := range ids {
+		if !slices.Contains(r.ids, id) {
+			r.ids = append(r.ids, id)
+		}
+	}
+}
+
+// Run refreshes the tracked businesses every interval until ctx is done. Errors
+// of a refresh are passed to onError, if not nil, and do not stop the
+// following ones.
+func (r *Refresher) Run(ctx context.Context, onError func(error)) error {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		if err := r.RefreshOnce(ctx); err != nil && onError != nil {
+			onError(err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// RefreshOnce fetches every tracked business and its reviews, upserts them
+// into the Store and publishes their changes. A business failing to refresh
+// does not stop the others; the errors are joined.
+func (r *Refresher) RefreshOnce(ctx context.Context) error {
+	r.mu.Lock()
+	ids := append([]string(nil), r.ids...)
+	publisher := r.publisher
+	r.mu.Unlock()
+
+	var errs []error
+	businesses := []Business{}
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			errs = append(errs, ctx.Err())
+			break
+		}
+		b, err := r.client.BusinessByID(ctx, id, BusinessOptions{})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		businesses = append(businesses, b)
+
+		var reviews []Review
+		rr, err := r.client.Reviews(ctx, id, ReviewsOptions{})
+		if err == nil {
+			reviews = append([]Review{}, rr.Reviews...)
+			if r.store != nil {
+				err = r.store.UpsertReviews(ctx, id, rr.Reviews)
+			}
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		r.mu.Lock()
+		events, state := changes(r.state[id], b, reviews, time.Now().UTC())
+		r.state[id] = state
+		r.mu.Unlock()
+		if publisher == nil {
+			continue
+		}
+		for _, e := range events {
+			if err := publisher.Publish(ctx, e); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if len(businesses) > 0 && r.store != nil {
+		if err := r.store.UpsertBusinesses(ctx, businesses); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
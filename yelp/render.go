@@ -0,0 +1,112 @@
+package yelp
+
+import (
+	htmltemplate "html/template"
+	"io"
+	"math"
+	"strings"
+	texttemplate "text/template"
+)
+
+// Default templates of a Renderer. Each defines a "business" template for a
+// single business and a "page" template for SearchResults.
+const (
+	DefaultMarkdownTemplate = `{{define "business"}}**[{{md .Name}}]({{.URL}})** {{stars .Rating}} {{.Rating}} ({{.ReviewCount}} reviews){{with .Price}} · {{.}}{{end}}
+{{with categories .}}_{{md .}}_
+{{end}}{{with address .}}{{md .}}
+{{end}}{{end}}{{define "page"}}{{range .Businesses}}{{template "business" .}}
+{{end}}{{end}}`
+
+	DefaultHTMLTemplate = `{{define "business"}}<div class="yelp-business">
+  <a href="{{.URL}}"><strong>{{.Name}}</strong></a>
+  <span class="yelp-rating" title="{{.Rating}} stars">{{stars .Rating}}</span> <span class="yelp-reviews">{{.ReviewCount}} reviews</span>{{with .Price}} <span class="yelp-price">{{.}}</span>{{end}}
+{{with categories .}}  <div class="yelp-categories">{{.}}</div>
+{{end}}{{with address .}}  <div class="yelp-address">{{.}}</div>
+{{end}}</div>
+{{end}}{{define "page"}}{{range .Businesses}}{{template "business" .}}{{end}}{{end}}`
+)
+
+// Renderer renders businesses and search results as Markdown or HTML cards
+// showing the name, rating, price, categories and link of each business.
+// The templates can be replaced or extended; they may use the functions
+// stars, categories, address and, for Markdown, md to escape text.
+type Renderer struct {
+	Markdown *texttemplate.Template
+	HTML     *htmltemplate.Template
+}
+
+// templateFuncs are the functions available to Renderer templates.
+var templateFuncs = map[string]interface{}{
+	"stars":      Stars,
+	"categories": categoriesString,
+	"address": func(b Business) string {
+		return strings.Join(b.Location.DisplayAddress, ", ")
+	},
+	"md": escapeMarkdown,
+}
+
+// NewRenderer returns a Renderer with the default templates.
+func NewRenderer() *Renderer {
+	return &Renderer{
+		Markdown: texttemplate.Must(texttemplate.New("markdown").Funcs(templateFuncs).Parse(DefaultMarkdownTemplate)),
+		HTML:     htmltemplate.Must(htmltemplate.New("html").Funcs(templateFuncs).Parse(DefaultHTMLTemplate)),
+	}
+}
+
+// BusinessMarkdown writes the Markdown card of b to w.
+func (r *Renderer) BusinessMarkdown(w io.Writer, b Business) error {
+	return r.Markdown.ExecuteTemplate(w, "business", b)
+}
+
+// PageMarkdown writes the Markdown cards of the businesses of sr to w.
+func (r *Renderer) PageMarkdown(w io.Writer, sr SearchResults) error {
+	return r.Markdown.ExecuteTemplate(w, "page", sr)
+}
+
+// BusinessHTML writes the HTML card of b to w.
+func (r *Renderer) BusinessHTML(w io.Writer, b Business) error {
+	return r.HTML.ExecuteTemplate(w, "business", b)
+}
+
+// PageHTML writes the HTML cards of the businesses of sr to w.
+func (r *Renderer) PageHTML(w io.Writer, sr SearchResults) error {
+	return r.HTML.ExecuteTemplate(w, "page", sr)
+}
+
+// Stars returns rating as five stars, e.g. "★★★½☆" for 3.5.
+func Stars(rating float64) string {
+	rating = math.Max(0, math.Min(5, rating))
+	full := int(rating)
+	half := rating-float64(full) >= 0.5
+	empty := 5 - full
+	if half {
+		empty--
+	}
+
+	s := strings.Repeat("★", full)
+	if half {
+		s += "½"
+	}
+	return s + strings.Repeat("☆", empty)
+}
+
+// categoriesString returns the titles of the categories of b separated by
+// commas.
+func categoriesString(b Business) string {
+	titles := make([]string, len(b.Categories))
+	for i, c := range b.Categories {
+		titles[i] = c.Title
+	}
+	return strings.Join(titles, ", ")
+}
+
+// markdownEscaper escapes the characters with a meaning in Markdown.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`,
+	"<", `\<`, ">", `\>`, "#", `\#`, "|", `\|`,
+)
+
+// escapeMarkdown escapes s to be shown as is in Markdown.
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
@@ -0,0 +1,83 @@
+package yelp
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Review is a review excerpt returned by the Reviews API.
+type Review struct {
+	ID          string  `json:"id"`
+	Rating      float64 `json:"rating"`
+	User        User    `json:"user"`
+	Text        string  `json:"text"`
+	TimeCreated string  `json:"time_created"`
+	URL         string  `json:"url"`
+
+	// OriginalText is the text as returned by Yelp when Text was translated.
+	OriginalText string `json:"-"`
+}
+
+// ReviewsOptions contains the available parameters for the Reviews API.
+type ReviewsOptions struct {
+	Locale *string
+}
+
+// URLValues returns ReviewsOptions as url.Values.
+func (ro ReviewsOptions) URLValues() url.Values {
+	vals := url.Values{}
+	if ro.Locale != nil {
+		vals.Add("locale", *ro.Locale)
+	}
+	return vals
+}
+
+// ReviewsResults reflects the JSON returned by the Reviews API.
+type ReviewsResults struct {
+	Total             int64    `json:"total"`
+	Reviews           []Review `json:"reviews"`
+	PossibleLanguages []string `json:"possible_languages"`
+
+	// Stale is true when the reviews were served from an expired cache entry
+	// because Yelp could not be reached, see WithStaleIfError.
+	Stale bool `json:"-"`
+}
+
+// Translator translates review excerpts. Languages are ISO 639-1 codes such
+// as "en", source is empty when the language of the text is unknown.
+type Translator interface {
+	Translate(text, source, target string) (string, error)
+}
+
+// TranslatorFunc is an adapter to allow the use of ordinary functions as
+// Translator.
+type TranslatorFunc func(text, source, target string) (string, error)
+
+// Translate calls f(text, source, target).
+func (f TranslatorFunc) Translate(text, source, target string) (string, error) {
+	return f(text, source, target)
+}
+
+// translate replaces the text of every review with its translation to target.
+func (rr *ReviewsResults) translate(t Translator, source, target string) error {
+	if source == target {
+		return nil
+	}
+	for i := range rr.Reviews {
+		r := &rr.Reviews[i]
+		text, err := t.Translate(r.Text, source, target)
+		if err != nil {
+			return err
+		}
+		r.OriginalText, r.Text = r.Text, text
+	}
+	return nil
+}
+
+// localeLanguage returns the language part of a locale such as "en_US".
+func localeLanguage(locale string) string {
+	if i := strings.IndexAny(locale, "_-"); i >= 0 {
+		return strings.ToLower(locale[:i])
+	}
+	return strings.ToLower(locale)
+}
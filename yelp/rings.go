@@ -0,0 +1,81 @@
+package yelp
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+// DefaultRings are the outer radii in meters of the rings of RingSearch when
+// none are given: walking distance, a short ride and a short drive.
+var DefaultRings = []int{1000, 3000, 5000}
+
+// Ring is a ring of a RingSearch with the businesses found within it.
+type Ring struct {
+	// Inner and Outer are the radii of the ring in meters, Inner included.
+	Inner int
+	Outer int
+
+	Businesses []Business
+}
+
+// RingSearch searches around center within each of the outer radii, in
+// meters and DefaultRings when none are given, and returns the businesses
+// grouped by the concentric ring they are in, e.g. 0-1km, 1-3km and 3-5km,
+// to group walkable places apart from the ones a short drive away. Every
+// radius is searched on its own and the businesses are placed in a ring by
+// their distance. Yelp has no inner radius, so the search of an outer ring
+// returns inner businesses too and the ring only gets the ones beyond its
+// inner radius: a large Limit in so leaves more for the outer rings.
+// Location, Coordinates and Radius of so are ignored.
+func (c *client) RingSearch(ctx context.Context, center Coordinates, so SearchOptions, radii ...int) ([]Ring, error) {
+	if len(radii) == 0 {
+		radii = DefaultRings
+	}
+	radii = append([]int(nil), radii...)
+	sort.Ints(radii)
+	if radii[0] <= 0 || radii[len(radii)-1] > maxSearchRadius {
+		return nil, errors.New("yelp: invalid ring radii")
+	}
+	so.Location = nil
+	so.Coordinates = &center
+
+	results := make([][]Business, len(radii))
+	errs := make([]error, len(radii))
+	c.forEach(ctx, progressFromContext(ctx), len(radii), func(i int) error {
+		rso := so
+		rso.Radius = Int64Ptr(int64(radii[i]))
+		var sr SearchResults
+		sr, errs[i] = c.Search(ctx, rso)
+		results[i] = sr.Businesses
+		return errs[i]
+	})
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	rings := make([]Ring, len(radii))
+	for i := range radii {
+		rings[i] = Ring{Outer: radii[i], Businesses: []Business{}}
+		if i > 0 {
+			rings[i].Inner = radii[i-1]
+		}
+	}
+	seen := map[string]bool{}
+	for _, businesses := range results {
+		for _, b := range businesses {
+			if seen[b.ID] {
+				continue
+			}
+			seen[b.ID] = true
+			i := sort.Search(len(radii), func(i int) bool { return b.Distance < float64(radii[i]) })
+			if i < len(rings) {
+				rings[i].Businesses = append(rings[i].Businesses, b)
+			}
+		}
+	}
+	return rings, nil
+}
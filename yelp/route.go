@@ -0,0 +1,121 @@
+package yelp
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+)
+
+// maxSearchRadius is the largest radius in meters of a search.
+const maxSearchRadius = 40000
+
+// RouteResult is a business found along a route.
+type RouteResult struct {
+	Business
+
+	// AlongRoute is the distance in meters from the start of the route to the
+	// point of the route closest to the business.
+	AlongRoute float64
+	// FromRoute is the distance in meters from the business to the route.
+	FromRoute float64
+}
+
+// SearchAlongRoute finds the businesses matching so within corridorMeters of
+// the route through the points of polyline, ordered by distance along the
+// route. The corridor is covered by tiles centered on points sampled along
+// the route, searched like the ones of TileSearch. Location, Coordinates,
+// Radius, Limit and Offset of so are ignored.
+func (c *client) SearchAlongRoute(ctx context.Context, polyline []Coordinates, corridorMeters int, so SearchOptions) ([]RouteResult, error) {
+	if len(polyline) == 0 || corridorMeters <= 0 {
+		return nil, errors.New("yelp: invalid route")
+	}
+	corridor := float64(corridorMeters)
+	so.Location, so.Limit, so.Offset, so.Radius = nil, nil, nil, nil
+
+	// Tiles every corridor meters with the disk inscribed in the square a
+	// tile is split in covering the corridor on both sides, so splitting
+	// tiles keeps the corridor covered.
+	step := corridor
+	radius := math.Min(math.Sqrt2*math.Hypot(corridor, step/2), maxSearchRadius)
+	tiles := []Tile{}
+	for _, p := range samplePolyline(polyline, step) {
+		tiles = append(tiles, Tile{Center: p, Radius: radius})
+	}
+
+	businesses, err := c.searchTiles(ctx, tiles, so, func(co Coordinates) bool {
+		_, off := projectOnRoute(polyline, co)
+		return off <= corridor
+	})
+
+	results := make([]RouteResult, len(businesses))
+	for i, b := range businesses {
+		results[i].Business = b
+		results[i].AlongRoute, results[i].FromRoute = projectOnRoute(polyline, b.Coodinates)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].AlongRoute < results[j].AlongRoute
+	})
+	return results, err
+}
+
+// samplePolyline returns points every step meters along the polyline, its
+// first and last points included.
+func samplePolyline(polyline []Coordinates, step float64) []Coordinates {
+	points := []Coordinates{polyline[0]}
+	carried := 0.0
+	for i := 1; i < len(polyline); i++ {
+		a, b := polyline[i-1], polyline[i]
+		length := distanceMeters(a, b)
+		for d := step - carried; d < length; d += step {
+			points = append(points, interpolate(a, b, d/length))
+		}
+		carried = math.Mod(carried+length, step)
+	}
+	if last := polyline[len(polyline)-1]; points[len(points)-1] != last {
+		points = append(points, last)
+	}
+	return points
+}
+
+// projectOnRoute returns the distance along the polyline to the point of the
+// polyline closest to c and the distance from c to it, in meters.
+func projectOnRoute(polyline []Coordinates, c Coordinates) (along, from float64) {
+	from = distanceMeters(polyline[0], c)
+	start := 0.0
+	for i := 1; i < len(polyline); i++ {
+		a, b := polyline[i-1], polyline[i]
+
+		// Project on the segment in a plane tangent at a.
+		kx := metersPerDegree * math.Cos(a.Latitude*math.Pi/180)
+		bx, by := (b.Longitude-a.Longitude)*kx, (b.Latitude-a.Latitude)*metersPerDegree
+		cx, cy := (c.Longitude-a.Longitude)*kx, (c.Latitude-a.Latitude)*metersPerDegree
+		length2 := bx*bx + by*by
+		t := 0.0
+		if length2 > 0 {
+			t = math.Max(0, math.Min(1, (cx*bx+cy*by)/length2))
+		}
+		if d := math.Hypot(cx-t*bx, cy-t*by); d < from {
+			along, from = start+t*math.Sqrt(length2), d
+		}
+		start += math.Sqrt(length2)
+	}
+	return along, from
+}
+
+// interpolate returns the point at fraction t of the way from a to b.
+func interpolate(a, b Coordinates, t float64) Coordinates {
+	return Coordinates{
+		Latitude:  a.Latitude + (b.Latitude-a.Latitude)*t,
+		Longitude: a.Longitude + (b.Longitude-a.Longitude)*t,
+	}
+}
+
+// distanceMeters returns the great circle distance between a and b.
+func distanceMeters(a, b Coordinates) float64 {
+	const earthRadius = 6371000.0
+	lat1, lat2 := a.Latitude*math.Pi/180, b.Latitude*math.Pi/180
+	dLat, dLng := lat2-lat1, (b.Longitude-a.Longitude)*math.Pi/180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(h))
+}
@@ -0,0 +1,63 @@
+package yelp
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// WeightFunc returns the weight of a business when sampling, zero or less to
+// never pick it.
+type WeightFunc func(Business) float64
+
+// QualityWeight weighs a business by its rating squared times the logarithm
+// of its review count, so well rated and often reviewed places come up more.
+func QualityWeight(b Business) float64 {
+	return b.Rating * b.Rating * math.Log1p(float64(b.ReviewCount))
+}
+
+// Sample returns up to n distinct businesses picked at random with a chance
+// proportional to their weight, QualityWeight when weight is nil, in the
+// order picked, e.g. for "surprise me" features. rng makes the picks
+// repeatable, as with rand.New(rand.NewSource(seed)); picks are random when
+// it is nil.
+func Sample(businesses []Business, n int, weight WeightFunc, rng *rand.Rand) []Business {
+	if weight == nil {
+		weight = QualityWeight
+	}
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	// Weighted sampling without replacement by Efraimidis and Spirakis: keep
+	// the n largest keys u^(1/w), u uniform in (0, 1).
+	type candidate struct {
+		i   int
+		key float64
+	}
+	candidates := make([]candidate, 0, len(businesses))
+	for i, b := range businesses {
+		w := weight(b)
+		if w <= 0 || math.IsNaN(w) {
+			continue
+		}
+		u := rng.Float64()
+		for u == 0 {
+			u = rng.Float64()
+		}
+		candidates = append(candidates, candidate{i: i, key: math.Log(u) / w})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].key > candidates[j].key
+	})
+	if n < len(candidates) {
+		candidates = candidates[:max(n, 0)]
+	}
+
+	picked := make([]Business, len(candidates))
+	for i, c := range candidates {
+		picked[i] = businesses[c.i]
+	}
+	return picked
+}
@@ -0,0 +1,95 @@
+package yelp
+
+import (
+	"context"
+	"errors"
+)
+
+// languageLocales are the locales used to request the reviews written in a
+// language. Yelp takes a locale, not a language, and any country works.
+var languageLocales = map[string]string{
+	"cs": "cs_CZ",
+	"da": "da_DK",
+	"de": "de_DE",
+	"en": "en_US",
+	"es": "es_ES",
+	"fi": "fi_FI",
+	"fr": "fr_FR",
+	"it": "it_IT",
+	"ja": "ja_JP",
+	"ms": "ms_MY",
+	"nb": "nb_NO",
+	"nl": "nl_NL",
+	"pl": "pl_PL",
+	"pt": "pt_BR",
+	"sv": "sv_SE",
+	"tr": "tr_TR",
+	"zh": "zh_HK",
+}
+
+// ReviewSampler collects as many review excerpts of a business as the API
+// gives out.
+//
+// The Reviews API returns at most 3 excerpts per business and has no
+// pagination: offset and limit are ignored for most API plans. The excerpts
+// returned depend on the locale though, as Yelp favors reviews written in
+// its language, so requesting the reviews in every language the business
+// has reviews in, as listed in ReviewsResults.PossibleLanguages, yields more
+// distinct reviews.
+type ReviewSampler struct {
+	client  Client
+	locales []string
+}
+
+// NewReviewSampler returns a ReviewSampler fetching reviews with c in the
+// given locales. When no locale is given, the reviews are fetched in the
+// locale of the client and then in every language the business has reviews
+// in.
+func NewReviewSampler(c Client, locales ...string) *ReviewSampler {
+	return &ReviewSampler{client: c, locales: locales}
+}
+
+// Sample returns the distinct reviews of the business with id businessID
+// found across locales, in the order they were first found. A locale
+// failing does not stop the others; the reviews found are returned with the
+// errors joined.
+func (rs *ReviewSampler) Sample(ctx context.Context, businessID string) ([]Review, error) {
+	reviews := []Review{}
+	seen := map[string]bool{}
+	add := func(rr ReviewsResults) {
+		for _, r := range rr.Reviews {
+			if !seen[r.ID] {
+				seen[r.ID] = true
+				reviews = append(reviews, r)
+			}
+		}
+	}
+
+	locales := rs.locales
+	if len(locales) == 0 {
+		rr, err := rs.client.Reviews(ctx, businessID, ReviewsOptions{})
+		if err != nil {
+			return reviews, err
+		}
+		add(rr)
+		for _, lang := range rr.PossibleLanguages {
+			if l, ok := languageLocales[lang]; ok {
+				locales = append(locales, l)
+			}
+		}
+	}
+
+	var errs []error
+	for _, l := range locales {
+		rr, err := rs.client.Reviews(ctx, businessID, ReviewsOptions{Locale: StringPtr(l)})
+		if err != nil {
+			errs = append(errs, err)
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+		add(rr)
+	}
+	return reviews, errors.Join(errs...)
+}
@@ -0,0 +1,91 @@
+package yelp
+
+import (
+	"context"
+	"time"
+)
+
+// SavedSearch is a search run repeatedly to find how its results change, e.g.
+// to notify users of new places matching it. It encodes to JSON with the
+// fingerprints of its last results, so it can be stored between runs.
+type SavedSearch struct {
+	Name    string        `json:"name"`
+	Options SearchOptions `json:"options"`
+
+	// LastRun is when the search last succeeded, zero before the first run.
+	LastRun time.Time `json:"last_run,omitempty"`
+
+	// Results are the businesses found by the last run.
+	Results []SavedResult `json:"results,omitempty"`
+}
+
+// SavedResult is a business found by a SavedSearch.
+type SavedResult struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// SearchDiff is how the results of a SavedSearch changed between two runs.
+type SearchDiff struct {
+	Added   []Business
+	Changed []Business
+
+	// Removed only have their ID and Name set.
+	Removed []Business
+}
+
+// IsEmpty returns true when the results did not change.
+func (d SearchDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Changed) == 0 && len(d.Removed) == 0
+}
+
+// NewSavedSearch returns a SavedSearch named name for so.
+func NewSavedSearch(name string, so SearchOptions) *SavedSearch {
+	return &SavedSearch{Name: name, Options: so}
+}
+
+// Run makes the search with c and returns the businesses added, changed and
+// removed since the last run, see Business.Fingerprint. Nothing is reported
+// on the first run. The saved results are only updated when the search
+// succeeds.
+func (ss *SavedSearch) Run(ctx context.Context, c Client) (SearchDiff, error) {
+	diff := SearchDiff{}
+	sr, err := c.Search(ctx, ss.Options)
+	if err != nil {
+		return diff, err
+	}
+
+	prev := map[string]SavedResult{}
+	for _, r := range ss.Results {
+		prev[r.ID] = r
+	}
+	first := ss.LastRun.IsZero()
+
+	results := make([]SavedResult, 0, len(sr.Businesses))
+	for _, b := range sr.Businesses {
+		r := SavedResult{ID: b.ID, Name: b.Name, Fingerprint: b.Fingerprint()}
+		results = append(results, r)
+
+		p, ok := prev[b.ID]
+		switch {
+		case first:
+		case !ok:
+			diff.Added = append(diff.Added, b)
+		case p.Fingerprint != r.Fingerprint:
+			diff.Changed = append(diff.Changed, b)
+		}
+		delete(prev, b.ID)
+	}
+	if !first {
+		for _, r := range ss.Results {
+			if _, ok := prev[r.ID]; ok {
+				diff.Removed = append(diff.Removed, Business{ID: r.ID, Name: r.Name})
+			}
+		}
+	}
+
+	ss.Results = results
+	ss.LastRun = time.Now().UTC()
+	return diff, nil
+}
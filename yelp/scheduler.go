@@ -0,0 +1,159 @@
+package yelp
+
+import (
+	"context"
+	"sync"
+)
+
+// tenantKey is the context key of the tenant label.
+type tenantKey struct{}
+
+// WithTenant returns a copy of ctx labeled with tenant. A Scheduler shares the
+// throughput fairly between the tenants of the requests it queues.
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext returns the tenant label of ctx, or an empty string when it
+// has none.
+func TenantFromContext(ctx context.Context) string {
+	tenant, _ := ctx.Value(tenantKey{}).(string)
+	return tenant
+}
+
+// Scheduler queues outgoing requests once a number of them are in flight and
+// sends the queued ones taking turns between tenants, so a tenant sending
+// many requests cannot starve the others. Requests without a tenant label
+// share the empty tenant. Queued interactive requests are sent before batch
+// ones, see WithPriority.
+type Scheduler struct {
+	mu    sync.Mutex
+	free  int
+	lanes [numPriorities]lane
+}
+
+// lane holds the queued requests of a priority.
+type lane struct {
+	queues map[string][]chan struct{}
+	turns  []string
+}
+
+// NewScheduler returns a Scheduler that allows up to concurrency requests in
+// flight at the same time.
+func NewScheduler(concurrency int) *Scheduler {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	s := &Scheduler{free: concurrency}
+	for i := range s.lanes {
+		s.lanes[i].queues = map[string][]chan struct{}{}
+	}
+	return s
+}
+
+// WithScheduler makes the client send its requests through s. A Scheduler can
+// be shared between clients using the same API key.
+func WithScheduler(s *Scheduler) Option {
+	return func(c *client) {
+		c.scheduler = s
+	}
+}
+
+// acquire waits for the turn of the tenant of ctx to send a request. release
+// must be called once the request is done.
+func (s *Scheduler) acquire(ctx context.Context) (release func(), err error) {
+	s.mu.Lock()
+	if s.free > 0 && s.idle() {
+		s.free--
+		s.mu.Unlock()
+		return s.release, nil
+	}
+
+	tenant := TenantFromContext(ctx)
+	l := &s.lanes[PriorityFromContext(ctx)]
+	ready := make(chan struct{})
+	if len(l.queues[tenant]) == 0 {
+		l.turns = append(l.turns, tenant)
+	}
+	l.queues[tenant] = append(l.queues[tenant], ready)
+	s.mu.Unlock()
+
+	select {
+	case <-ready:
+		return s.release, nil
+	case <-ctx.Done():
+	}
+
+	s.mu.Lock()
+	queued := l.dequeue(tenant, ready)
+	s.mu.Unlock()
+	if !queued {
+		// The turn was given while giving up, pass it on.
+		s.release()
+	}
+	return nil, ctx.Err()
+}
+
+// idle returns true when no request is queued.
+func (s *Scheduler) idle() bool {
+	for i := range s.lanes {
+		if len(s.lanes[i].turns) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// release gives the freed slot to the next tenant in turn of the most urgent
+// priority with queued requests.
+func (s *Scheduler) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.lanes {
+		if l := &s.lanes[i]; len(l.turns) > 0 {
+			l.next()
+			return
+		}
+	}
+	s.free++
+}
+
+// next gives the turn to the next tenant of the lane.
+func (l *lane) next() {
+	tenant := l.turns[0]
+	l.turns = l.turns[1:]
+	queue := l.queues[tenant]
+	ready := queue[0]
+	if len(queue) > 1 {
+		l.queues[tenant] = queue[1:]
+		l.turns = append(l.turns, tenant)
+	} else {
+		delete(l.queues, tenant)
+	}
+	close(ready)
+}
+
+// dequeue removes ready from the queue of tenant, returning false when it was
+// not queued anymore.
+func (l *lane) dequeue(tenant string, ready chan struct{}) bool {
+	queue := l.queues[tenant]
+	for i, ch := range queue {
+		if ch != ready {
+			continue
+		}
+		queue = append(queue[:i:i], queue[i+1:]...)
+		if len(queue) > 0 {
+			l.queues[tenant] = queue
+			return true
+		}
+		delete(l.queues, tenant)
+		for j, t := range l.turns {
+			if t == tenant {
+				l.turns = append(l.turns[:j:j], l.turns[j+1:]...)
+				break
+			}
+		}
+		return true
+	}
+	return false
+}
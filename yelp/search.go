@@ -1,22 +1,64 @@
 package yelp
 
-import "net/url"
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
 
-// SearchOptions contains the available parameters for the Search API.
+// SearchOptions contains the available parameters for the Search API. It
+// encodes to JSON with the names of the parameters, so searches can be stored
+// and replayed.
 type SearchOptions struct {
-	Term        *string
-	Location    *string
-	Coordinates *Coordinates
-	Radius      *int64
-	Categories  *string
-	Locale      *string
-	Limit       *int64
-	Offset      *int64
-	SortBy      *string
-	Price       *string
-	OpenNow     *bool
-	OpenAt      *int64
-	Attributes  *string
+	Term        *string      `json:"term,omitempty"`
+	Location    *string      `json:"location,omitempty"`
+	Coordinates *Coordinates `json:"coordinates,omitempty"`
+	Radius      *int64       `json:"radius,omitempty"`
+	Categories  *string      `json:"categories,omitempty"`
+	Locale      *string      `json:"locale,omitempty"`
+	Limit       *int64       `json:"limit,omitempty"`
+	Offset      *int64       `json:"offset,omitempty"`
+	SortBy      *string      `json:"sort_by,omitempty"`
+	Price       *string      `json:"price,omitempty"`
+	OpenNow     *bool        `json:"open_now,omitempty"`
+	OpenAt      *int64       `json:"open_at,omitempty"`
+	Attributes  []Attribute  `json:"attributes,omitempty"`
+
+	DevicePlatform DevicePlatform `json:"device_platform,omitempty"`
+}
+
+// UnmarshalJSON decodes SearchOptions encoded by encoding/json. open_at may
+// also be an RFC 3339 time, and an unknown device_platform is an error.
+func (so *SearchOptions) UnmarshalJSON(data []byte) error {
+	type searchOptions SearchOptions
+	aux := struct {
+		*searchOptions
+		OpenAt json.RawMessage `json:"open_at,omitempty"`
+	}{searchOptions: (*searchOptions)(so)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	so.OpenAt = nil
+	if len(aux.OpenAt) > 0 && string(aux.OpenAt) != "null" {
+		var unix int64
+		var t time.Time
+		if err := json.Unmarshal(aux.OpenAt, &unix); err == nil {
+			so.OpenAt = &unix
+		} else if err := json.Unmarshal(aux.OpenAt, &t); err == nil {
+			so.OpenAt = Int64Ptr(t.Unix())
+		} else {
+			return fmt.Errorf("yelp: invalid open_at %s", aux.OpenAt)
+		}
+	}
+
+	switch so.DevicePlatform {
+	case "", DevicePlatformAndroid, DevicePlatformIOS, DevicePlatformMobileGeneric:
+	default:
+		return fmt.Errorf("yelp: unknown device_platform %q", so.DevicePlatform)
+	}
+	return nil
 }
 
 // SearchResults reflects the JSON returned by the Search API.
@@ -24,6 +66,20 @@ type SearchResults struct {
 	Total      int64      `json:"total"`
 	Businesses []Business `json:"businesses"`
 	Region     Region     `json:"region"`
+
+	// Locale is the locale the search was made with, if any.
+	Locale string `json:"-"`
+
+	// Stale is true when the results were served from an expired cache
+	// entry because Yelp could not be reached, see WithStaleIfError.
+	Stale bool `json:"-"`
+}
+
+// WithSearchRadius sets the radius in meters of the searches made without one.
+func WithSearchRadius(meters int64) Option {
+	return func(c *client) {
+		c.searchRadius = &meters
+	}
 }
 
 // IsValid returns true when either Location or Coordinates is set and OpenNow
@@ -72,8 +128,11 @@ func (so SearchOptions) URLValues() url.Values {
 	if so.OpenAt != nil {
 		vals.Add("open_at", IntString(*so.OpenAt))
 	}
-	if so.Attributes != nil {
-		vals.Add("attributes", *so.Attributes)
+	if len(so.Attributes) > 0 {
+		vals.Add("attributes", AttributesString(so.Attributes))
+	}
+	if so.DevicePlatform != "" {
+		vals.Add("device_platform", string(so.DevicePlatform))
 	}
 	return vals
 }
@@ -0,0 +1,37 @@
+package yelp
+
+import (
+	"net/http"
+	"time"
+)
+
+// serverlessIdleConnTimeout is how long the connections of a serverless
+// client are kept idle. It outlives the pause between the invocations of a
+// warm function instance.
+const serverlessIdleConnTimeout = 5 * time.Minute
+
+// WithServerless tunes the client for AWS Lambda, Cloud Functions and other
+// environments freezing the process between invocations:
+//
+//   - no background goroutine outlives a call, so stale cache entries are
+//     refetched instead of being served while revalidated, see
+//     WithStaleWhileRevalidate;
+//   - connections are kept idle long enough to be reused by the next
+//     invocations, over HTTP/2 when the server supports it.
+//
+// Create the client once, outside of the handler, so its connections are
+// reused, and call WarmUp during the init phase. Quotas shared by the
+// instances are enforced with a QuotaManager counting in a shared QuotaStore,
+// see NewQuotaManagerWithStore.
+func WithServerless() Option {
+	return func(c *client) {
+		c.serverless = true
+		c.withTransport(func(t *http.Transport) {
+			t.ForceAttemptHTTP2 = true
+			t.IdleConnTimeout = serverlessIdleConnTimeout
+			if t.MaxIdleConnsPerHost < warmUpConns {
+				t.MaxIdleConnsPerHost = warmUpConns
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package yelp
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// Snapshot is the stored output of crawling an area at a point in time.
+type Snapshot struct {
+	Area       string     `json:"area"`
+	Categories []string   `json:"categories,omitempty"`
+	TakenAt    time.Time  `json:"taken_at"`
+	Businesses []Business `json:"businesses"`
+}
+
+// ReadSnapshot decodes a Snapshot written by Snapshot.WriteTo.
+func ReadSnapshot(r io.Reader) (Snapshot, error) {
+	s := Snapshot{}
+	err := json.NewDecoder(r).Decode(&s)
+	return s, err
+}
+
+// WriteTo writes the snapshot to w as JSON.
+func (s Snapshot) WriteTo(w io.Writer) (int64, error) {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(append(b, '\n'))
+	return int64(n), err
+}
+
+// Fingerprint returns a hash of the business information, so two copies of a
+// business fetched at different times compare equal unless it changed. The
+// distance, which depends on the search, is left out.
+func (b Business) Fingerprint() string {
+	b.Distance = 0
+	data, _ := json.Marshal(b)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
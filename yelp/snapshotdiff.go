@@ -0,0 +1,168 @@
+package yelp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SnapshotDiff holds the businesses added, removed and changed from a
+// Snapshot to a later one, sorted by id.
+type SnapshotDiff struct {
+	From    time.Time        `json:"from"`
+	To      time.Time        `json:"to"`
+	Added   []Business       `json:"added"`
+	Removed []Business       `json:"removed"`
+	Changed []BusinessChange `json:"changed"`
+}
+
+// BusinessChange is a business found in both snapshots with a different
+// Fingerprint. Fields is empty when only details not compared changed.
+type BusinessChange struct {
+	Old    Business      `json:"old"`
+	New    Business      `json:"new"`
+	Fields []FieldChange `json:"fields"`
+}
+
+// FieldChange is a field of a business whose value changed.
+type FieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old"`
+	New   interface{} `json:"new"`
+}
+
+// diffFields are the fields compared by DiffSnapshots, named as in the JSON
+// of the Yelp API.
+var diffFields = []struct {
+	name  string
+	value func(Business) interface{}
+}{
+	{"name", func(b Business) interface{} { return b.Name }},
+	{"is_closed", func(b Business) interface{} { return b.IsClosed }},
+	{"rating", func(b Business) interface{} { return b.Rating }},
+	{"review_count", func(b Business) interface{} { return b.ReviewCount }},
+	{"price", func(b Business) interface{} { return b.Price }},
+	{"phone", func(b Business) interface{} { return b.Phone }},
+	{"location", func(b Business) interface{} { return strings.Join(b.Location.DisplayAddress, ", ") }},
+	{"coordinates", func(b Business) interface{} { return b.Coodinates }},
+	{"categories", func(b Business) interface{} { return categoryAliases(b) }},
+	{"url", func(b Business) interface{} { return b.URL }},
+}
+
+// DiffSnapshots returns the businesses added, removed and changed from old to
+// new, compared by their Fingerprint.
+func DiffSnapshots(old, new Snapshot) SnapshotDiff {
+	d := SnapshotDiff{
+		From:    old.TakenAt,
+		To:      new.TakenAt,
+		Added:   []Business{},
+		Removed: []Business{},
+		Changed: []BusinessChange{},
+	}
+	prev := make(map[string]Business, len(old.Businesses))
+	for _, b := range old.Businesses {
+		prev[b.ID] = b
+	}
+	seen := make(map[string]bool, len(new.Businesses))
+	for _, b := range new.Businesses {
+		seen[b.ID] = true
+		p, ok := prev[b.ID]
+		switch {
+		case !ok:
+			d.Added = append(d.Added, b)
+		case p.Fingerprint() != b.Fingerprint():
+			d.Changed = append(d.Changed, BusinessChange{Old: p, New: b, Fields: diffBusiness(p, b)})
+		}
+	}
+	for _, b := range old.Businesses {
+		if !seen[b.ID] {
+			d.Removed = append(d.Removed, b)
+		}
+	}
+
+	sort.Slice(d.Added, func(i, j int) bool { return d.Added[i].ID < d.Added[j].ID })
+	sort.Slice(d.Removed, func(i, j int) bool { return d.Removed[i].ID < d.Removed[j].ID })
+	sort.Slice(d.Changed, func(i, j int) bool { return d.Changed[i].New.ID < d.Changed[j].New.ID })
+	return d
+}
+
+// diffBusiness returns the compared fields whose value differs from old to
+// new.
+func diffBusiness(old, new Business) []FieldChange {
+	changes := []FieldChange{}
+	for _, f := range diffFields {
+		o, n := f.value(old), f.value(new)
+		if !reflect.DeepEqual(o, n) {
+			changes = append(changes, FieldChange{Field: f.name, Old: o, New: n})
+		}
+	}
+	return changes
+}
+
+// categoryAliases returns the aliases of the categories of b.
+func categoryAliases(b Business) []string {
+	aliases := make([]string, len(b.Categories))
+	for i, c := range b.Categories {
+		aliases[i] = c.Alias
+	}
+	return aliases
+}
+
+// Empty returns true when nothing changed.
+func (d SnapshotDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// WriteJSON writes the diff to w as JSON.
+func (d SnapshotDiff) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(d)
+}
+
+// WriteText writes the diff to w for people to read: a summary, then a line
+// per business added (+), removed (-) and changed (~), e.g.
+//
+//	3 added, 1 removed, 1 changed from 2024-05-01 to 2024-06-01
+//	+ new-cafe-berlin  New Cafe
+//	- old-bar-berlin   Old Bar
+//	~ some-bistro      Some Bistro: rating 4 → 4.5, review_count 120 → 131
+func (d SnapshotDiff) WriteText(w io.Writer) error {
+	ew := &errWriter{w: w}
+	ew.printf("%d added, %d removed, %d changed from %s to %s\n",
+		len(d.Added), len(d.Removed), len(d.Changed), d.From.Format(time.DateOnly), d.To.Format(time.DateOnly))
+	for _, b := range d.Added {
+		ew.printf("+ %s\t%s\n", b.ID, b.Name)
+	}
+	for _, b := range d.Removed {
+		ew.printf("- %s\t%s\n", b.ID, b.Name)
+	}
+	for _, c := range d.Changed {
+		fields := make([]string, len(c.Fields))
+		for i, f := range c.Fields {
+			fields[i] = fmt.Sprintf("%s %v → %v", f.Field, f.Old, f.New)
+		}
+		if len(fields) == 0 {
+			fields = append(fields, "other details")
+		}
+		ew.printf("~ %s\t%s: %s\n", c.New.ID, c.New.Name, strings.Join(fields, ", "))
+	}
+	return ew.err
+}
+
+// errWriter writes to w until an error occurs, keeping the first one.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+// printf writes a formatted string unless a previous write failed.
+func (ew *errWriter) printf(format string, args ...interface{}) {
+	if ew.err == nil {
+		_, ew.err = fmt.Fprintf(ew.w, format, args...)
+	}
+}
@@ -0,0 +1,132 @@
+package yelp
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// StaticMapProvider is a service rendering static map images.
+type StaticMapProvider string
+
+// Available static map providers.
+const (
+	StaticMapGoogle StaticMapProvider = "google"
+	StaticMapOSM    StaticMapProvider = "osm"
+)
+
+const (
+	// googleStaticMapURL is the endpoint of the Google Maps Static API
+	googleStaticMapURL = "https://maps.googleapis.com/maps/api/staticmap"
+
+	// osmStaticMapURL is the endpoint of the OpenStreetMap static map service
+	osmStaticMapURL = "https://staticmap.openstreetmap.de/staticmap.php"
+
+	// defaultStaticMapZoom is the zoom of OpenStreetMap maps without one
+	defaultStaticMapZoom = 14
+
+	// markerLabels are the labels of the markers, in order
+	markerLabels = "123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+)
+
+// StaticMap builds URLs of static map images with a marker per business, for
+// emails and other places where maps cannot run JavaScript.
+type StaticMap struct {
+	Provider StaticMapProvider
+	Width    int
+	Height   int
+
+	// Zoom is the zoom level of the map. Google fits the map to the markers
+	// when it is zero, OpenStreetMap uses a zoom of 14.
+	Zoom int
+
+	// Center is the center of the map. The center of the markers is used
+	// when it is nil.
+	Center *Coordinates
+
+	// APIKey is required by Google.
+	APIKey string
+}
+
+// URL returns the URL of the map image with the businesses as markers.
+// Google markers are labeled 1-9 then A-Z in the order of businesses.
+// Businesses without coordinates are left out.
+func (sm StaticMap) URL(businesses []Business) string {
+	width, height := sm.Width, sm.Height
+	if width <= 0 {
+		width = 600
+	}
+	if height <= 0 {
+		height = 400
+	}
+	size := strconv.Itoa(width) + "x" + strconv.Itoa(height)
+
+	points := []Coordinates{}
+	for _, b := range businesses {
+		if b.Coodinates.isSet() {
+			points = append(points, b.Coodinates)
+		}
+	}
+	center := sm.Center
+	if center == nil && len(points) > 0 {
+		c := centerOf(points)
+		center = &c
+	}
+
+	vals := url.Values{}
+	if sm.Provider == StaticMapOSM {
+		zoom := sm.Zoom
+		if zoom <= 0 {
+			zoom = defaultStaticMapZoom
+		}
+		vals.Set("size", size)
+		vals.Set("zoom", strconv.Itoa(zoom))
+		if center != nil {
+			vals.Set("center", latLng(*center))
+		}
+		markers := make([]string, len(points))
+		for i, p := range points {
+			markers[i] = latLng(p) + ",red-pushpin"
+		}
+		if len(markers) > 0 {
+			vals.Set("markers", strings.Join(markers, "|"))
+		}
+		return osmStaticMapURL + "?" + vals.Encode()
+	}
+
+	vals.Set("size", size)
+	if sm.Zoom > 0 {
+		vals.Set("zoom", strconv.Itoa(sm.Zoom))
+	}
+	if sm.Center != nil {
+		vals.Set("center", latLng(*sm.Center))
+	}
+	for i, p := range points {
+		marker := latLng(p)
+		if i < len(markerLabels) {
+			marker = "label:" + markerLabels[i:i+1] + "|" + marker
+		}
+		vals.Add("markers", marker)
+	}
+	if sm.APIKey != "" {
+		vals.Set("key", sm.APIKey)
+	}
+	return googleStaticMapURL + "?" + vals.Encode()
+}
+
+// latLng returns the coordinates as "latitude,longitude".
+func latLng(c Coordinates) string {
+	return FloatString(c.Latitude) + "," + FloatString(c.Longitude)
+}
+
+// centerOf returns the average of points.
+func centerOf(points []Coordinates) Coordinates {
+	c := Coordinates{}
+	for _, p := range points {
+		c.Latitude += p.Latitude
+		c.Longitude += p.Longitude
+	}
+	c.Latitude /= float64(len(points))
+	c.Longitude /= float64(len(points))
+	return c
+}
@@ -0,0 +1,82 @@
+package yelp
+
+import (
+	"context"
+	"sort"
+)
+
+// AreaStats are aggregate statistics of the businesses of a search.
+type AreaStats struct {
+	// Count is the number of businesses the statistics are computed from and
+	// Total the number reported by Yelp, larger when the search has more
+	// results than can be paginated.
+	Count int
+	Total int64
+
+	AverageRating float64
+	// RatingHistogram counts the businesses by rating in half stars, from 0
+	// to 10, see Business.RatingHalfStars.
+	RatingHistogram [11]int
+	// PriceLevels counts the businesses by price level, 1 to 4 in any
+	// currency, with 0 for the ones without, see Business.PriceLevel.
+	PriceLevels [5]int
+	// Categories counts the businesses by category, most common first.
+	Categories []CategoryCount
+}
+
+// CategoryCount is the number of businesses of a category.
+type CategoryCount struct {
+	Category
+	Count int
+}
+
+// ComputeAreaStats returns the statistics of businesses.
+func ComputeAreaStats(businesses []Business) AreaStats {
+	st := AreaStats{Count: len(businesses), Total: int64(len(businesses))}
+	counts := map[string]*CategoryCount{}
+	var sum float64
+	for _, b := range businesses {
+		sum += b.Rating
+		st.RatingHistogram[b.RatingHalfStars()]++
+		st.PriceLevels[b.PriceLevel().Level]++
+		for _, cat := range b.Categories {
+			cc := counts[cat.Alias]
+			if cc == nil {
+				cc = &CategoryCount{Category: cat}
+				counts[cat.Alias] = cc
+			}
+			cc.Count++
+		}
+	}
+	if len(businesses) > 0 {
+		st.AverageRating = sum / float64(len(businesses))
+	}
+
+	st.Categories = make([]CategoryCount, 0, len(counts))
+	for _, cc := range counts {
+		st.Categories = append(st.Categories, *cc)
+	}
+	sort.Slice(st.Categories, func(i, j int) bool {
+		if st.Categories[i].Count != st.Categories[j].Count {
+			return st.Categories[i].Count > st.Categories[j].Count
+		}
+		return st.Categories[i].Alias < st.Categories[j].Alias
+	})
+	return st
+}
+
+// AreaStats paginates the search so and returns the statistics of its
+// businesses, for market research. Yelp paginates the first 1000 results of
+// a search only; see TileSearch to cover larger areas.
+func (c *client) AreaStats(ctx context.Context, so SearchOptions) (AreaStats, error) {
+	p := SearchPages(c, so)
+	businesses, err := p.All(ctx)
+	if err != nil {
+		return AreaStats{}, err
+	}
+	st := ComputeAreaStats(businesses)
+	if total := p.Total(); total > st.Total {
+		st.Total = total
+	}
+	return st, nil
+}
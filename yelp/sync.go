@@ -0,0 +1,179 @@
+package yelp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+)
+
+// SyncEntry is what a SyncStore remembers of a stored business to sync it.
+type SyncEntry struct {
+	ID string
+
+	// ETag identifies the stored version of the business, its Fingerprint.
+	// Yelp has neither entity tags nor conditional requests, so it is
+	// compared after fetching the business.
+	ETag string
+
+	// SyncedAt is the time the business was last fetched, zero when never.
+	SyncedAt time.Time
+
+	// Deleted is true when Yelp no longer knows the business, or answers
+	// with another one it was merged into. Deleted businesses are not
+	// fetched again.
+	Deleted bool
+}
+
+// SyncStore is a Store keeping the SyncEntry of its businesses.
+type SyncStore interface {
+	Store
+
+	// SyncEntries returns the entries of the stored businesses.
+	SyncEntries(ctx context.Context) ([]SyncEntry, error)
+
+	// PutSyncEntries inserts the entries, or updates them when they are
+	// already stored.
+	PutSyncEntries(ctx context.Context, entries []SyncEntry) error
+}
+
+// SyncOptions configures Sync.
+type SyncOptions struct {
+	// MaxAge is the age from which stored businesses are fetched again.
+	// Every business is fetched when zero.
+	MaxAge time.Duration
+
+	// Log, if not nil, receives a SyncChange per line as JSON for every
+	// business updated, deleted or merged.
+	Log io.Writer
+
+	// Clock tells the time of the sync, the system clock when nil.
+	Clock Clock
+}
+
+// SyncChangeKind is the kind of a SyncChange.
+type SyncChangeKind string
+
+// Available sync change kinds.
+const (
+	SyncUpdated SyncChangeKind = "updated"
+	SyncDeleted SyncChangeKind = "deleted"
+
+	// SyncMerged is a business Yelp answers with another one, as when
+	// duplicate listings are merged. The stored business is not updated;
+	// MergedInto is the id to remap it to.
+	SyncMerged SyncChangeKind = "merged"
+)
+
+// SyncChange is a line of the change log of Sync.
+type SyncChange struct {
+	Kind       SyncChangeKind `json:"kind"`
+	BusinessID string         `json:"business_id"`
+	OldETag    string         `json:"old_etag,omitempty"`
+	NewETag    string         `json:"new_etag,omitempty"`
+	MergedInto string         `json:"merged_into,omitempty"`
+	SyncedAt   time.Time      `json:"synced_at"`
+}
+
+// SyncReport counts the businesses of a Sync.
+type SyncReport struct {
+	// Fresh businesses were synced less than MaxAge ago and not fetched.
+	Fresh     int
+	Unchanged int
+	Updated   int
+	Deleted   int
+	Merged    int
+	Failed    int
+}
+
+// Sync fetches the businesses of s last synced at least opts.MaxAge ago,
+// several at a time, reporting progress as set with WithProgress. Every due
+// business is fetched in full, as Yelp has no conditional requests; only the
+// ones whose ETag changed are upserted and written to the change log, and
+// the entries of all the businesses fetched are updated. Businesses deleted
+// or merged into another one are logged and not synced again. A business
+// failing to load does not stop the others and the errors are joined.
+func Sync(ctx context.Context, c Client, s SyncStore, opts SyncOptions) (SyncReport, error) {
+	report := SyncReport{}
+	entries, err := s.SyncEntries(ctx)
+	if err != nil {
+		return report, err
+	}
+
+	clock := opts.Clock
+	if clock == nil {
+		clock = systemClock{}
+	}
+	now := clock.Now().UTC()
+	due := []SyncEntry{}
+	for _, e := range entries {
+		if e.Deleted {
+			continue
+		}
+		if opts.MaxAge > 0 && now.Sub(e.SyncedAt) < opts.MaxAge {
+			report.Fresh++
+			continue
+		}
+		due = append(due, e)
+	}
+
+	found := make([]Business, len(due))
+	errs := make([]error, len(due))
+	runBatch(ctx, progressFromContext(ctx), batchWorkers, len(due), func(i int) error {
+		found[i], errs[i] = c.BusinessByID(ctx, due[i].ID, BusinessOptions{})
+		return errs[i]
+	})
+
+	updated := []Business{}
+	synced := []SyncEntry{}
+	changes := []SyncChange{}
+	for i, e := range due {
+		switch {
+		case errs[i] == nil && found[i].ID != "" && found[i].ID != e.ID && found[i].Alias != e.ID:
+			report.Merged++
+			changes = append(changes, SyncChange{Kind: SyncMerged, BusinessID: e.ID, OldETag: e.ETag, MergedInto: found[i].ID, SyncedAt: now})
+			synced = append(synced, SyncEntry{ID: e.ID, ETag: e.ETag, SyncedAt: now, Deleted: true})
+		case errs[i] == nil && found[i].ID != "":
+			etag := found[i].Fingerprint()
+			if etag == e.ETag {
+				report.Unchanged++
+			} else {
+				report.Updated++
+				updated = append(updated, found[i])
+				changes = append(changes, SyncChange{Kind: SyncUpdated, BusinessID: e.ID, OldETag: e.ETag, NewETag: etag, SyncedAt: now})
+			}
+			synced = append(synced, SyncEntry{ID: e.ID, ETag: etag, SyncedAt: now})
+		case ClassifyError(errs[i]) == ErrorClassNotFound:
+			report.Deleted++
+			changes = append(changes, SyncChange{Kind: SyncDeleted, BusinessID: e.ID, OldETag: e.ETag, SyncedAt: now})
+			synced = append(synced, SyncEntry{ID: e.ID, ETag: e.ETag, SyncedAt: now, Deleted: true})
+			errs[i] = nil
+		default:
+			report.Failed++
+		}
+	}
+
+	if len(updated) > 0 {
+		if err := s.UpsertBusinesses(ctx, updated); err != nil {
+			return report, err
+		}
+	}
+	if len(synced) > 0 {
+		if err := s.PutSyncEntries(ctx, synced); err != nil {
+			return report, err
+		}
+	}
+	if opts.Log != nil {
+		enc := json.NewEncoder(opts.Log)
+		for _, ch := range changes {
+			if err := enc.Encode(ch); err != nil {
+				return report, err
+			}
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return report, err
+	}
+	return report, ctx.Err()
+}
@@ -0,0 +1,185 @@
+package yelp
+
+import (
+	"context"
+	"errors"
+	"math"
+)
+
+const (
+	// defaultTileRadius is the radius in meters of the tiles of a TileSearch
+	// made without one.
+	defaultTileRadius = 2000
+
+	// minTileRadius is the smallest radius in meters tiles are split to.
+	minTileRadius = 100
+
+	// metersPerDegree is the length of a degree of latitude in meters.
+	metersPerDegree = 111320.0
+)
+
+// Bounds is a rectangular area.
+type Bounds struct {
+	SouthWest Coordinates
+	NorthEast Coordinates
+}
+
+// Contains returns true when c is within the bounds.
+func (b Bounds) Contains(c Coordinates) bool {
+	return c.Latitude >= b.SouthWest.Latitude && c.Latitude <= b.NorthEast.Latitude &&
+		c.Longitude >= b.SouthWest.Longitude && c.Longitude <= b.NorthEast.Longitude
+}
+
+// IsValid returns true when the south west corner is south west of the north
+// east one.
+func (b Bounds) IsValid() bool {
+	return b.SouthWest.Latitude < b.NorthEast.Latitude && b.SouthWest.Longitude < b.NorthEast.Longitude
+}
+
+// Tile is a circular search area of a TileSearch.
+type Tile struct {
+	Center Coordinates `json:"center"`
+	Radius float64     `json:"radius"`
+}
+
+// TileSearch finds the businesses within bounds matching so by covering the
+// area with circular searches of so.Radius meters, 2000 when nil, and
+// paginating each of them. Tiles with more results than Yelp returns for a
+// search are split in four. A Radius that is not positive is an error.
+// Location, Coordinates, Limit and Offset of so are ignored. Tiles are searched several at a time, see
+// WithDeterministicOrder, and progress is reported per tile as set with
+// WithProgress. Each business is returned once, in tile order.
+func (c *client) TileSearch(ctx context.Context, bounds Bounds, so SearchOptions) ([]Business, error) {
+	if !bounds.IsValid() {
+		return nil, errors.New("yelp: invalid bounds")
+	}
+	radius := float64(defaultTileRadius)
+	if so.Radius != nil {
+		if *so.Radius <= 0 {
+			return nil, errors.New("yelp: tile radius must be positive")
+		}
+		radius = float64(*so.Radius)
+	}
+	so.Location, so.Limit, so.Offset = nil, nil, nil
+	return c.searchTiles(ctx, CoverBounds(bounds, radius), so, bounds.Contains)
+}
+
+// searchTiles searches the tiles, splitting the ones with too many results,
+// and returns the businesses at coordinates keep returns true for, once each
+// and in tile order.
+func (c *client) searchTiles(ctx context.Context, queue []Tile, so SearchOptions, keep func(Coordinates) bool) ([]Business, error) {
+	var firstErr error
+	seen := map[string]bool{}
+	found := []Business{}
+
+	pt := progressFromContext(ctx)
+	for len(queue) > 0 && firstErr == nil && ctx.Err() == nil {
+		batch := queue
+		results := make([]tileResult, len(batch))
+		c.forEach(ctx, pt, len(batch), func(i int) error {
+			r := &results[i]
+			r.businesses, r.split, r.err = c.searchTile(ctx, batch[i], so)
+			return r.err
+		})
+
+		// Merge in tile order, so the results do not depend on which search
+		// finished first.
+		queue = nil
+		for _, r := range results {
+			if r.err != nil && firstErr == nil {
+				firstErr = r.err
+			}
+			queue = append(queue, r.split...)
+			for _, b := range r.businesses {
+				if !seen[b.ID] && keep(b.Coodinates) {
+					seen[b.ID] = true
+					found = append(found, b)
+				}
+			}
+		}
+	}
+
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	return found, firstErr
+}
+
+// tileResult is the outcome of searchTile.
+type tileResult struct {
+	businesses []Business
+	split      []Tile
+	err        error
+}
+
+// searchTile returns the businesses of the tile, or the tiles it is split in
+// when it has more results than can be paginated.
+func (c *client) searchTile(ctx context.Context, t Tile, so SearchOptions) ([]Business, []Tile, error) {
+	p := SearchPages(c, t.SearchOptions(so))
+	businesses, err := p.Next(ctx)
+	if err != nil {
+		return businesses, nil, err
+	}
+	if p.Total() > maxSearchWindow && t.CanSplit() {
+		return nil, t.Split(), nil
+	}
+	rest, err := p.All(ctx)
+	return append(businesses, rest...), nil, err
+}
+
+// CoverBounds returns tiles of radius meters covering bounds, nil when radius
+// is not positive.
+func CoverBounds(bounds Bounds, radius float64) []Tile {
+	if radius <= 0 {
+		return nil
+	}
+	// Circles on a grid of step r√2 cover the squares they are centered in.
+	step := radius * math.Sqrt2
+	tiles := []Tile{}
+	for lat := bounds.SouthWest.Latitude + step/2/metersPerDegree; ; lat += step / metersPerDegree {
+		lngStep := step / (metersPerDegree * math.Cos(lat*math.Pi/180))
+		for lng := bounds.SouthWest.Longitude + lngStep/2; ; lng += lngStep {
+			tiles = append(tiles, Tile{Center: Coordinates{Latitude: lat, Longitude: lng}, Radius: radius})
+			if lng+lngStep/2 >= bounds.NorthEast.Longitude {
+				break
+			}
+		}
+		if lat+step/2/metersPerDegree >= bounds.NorthEast.Latitude {
+			break
+		}
+	}
+	return tiles
+}
+
+// SearchOptions returns so searching the tile.
+func (t Tile) SearchOptions(so SearchOptions) SearchOptions {
+	center := t.Center
+	so.Location = nil
+	so.Coordinates = &center
+	so.Radius = Int64Ptr(int64(math.Ceil(t.Radius)))
+	return so
+}
+
+// CanSplit returns true when the tile is large enough to be split.
+func (t Tile) CanSplit() bool {
+	return t.Radius/2 >= minTileRadius
+}
+
+// Split returns four tiles of half the radius covering the square the tile
+// is centered in.
+func (t Tile) Split() []Tile {
+	offset := t.Radius * math.Sqrt2 / 4
+	latOffset := offset / metersPerDegree
+	lngOffset := offset / (metersPerDegree * math.Cos(t.Center.Latitude*math.Pi/180))
+
+	tiles := make([]Tile, 0, 4)
+	for _, dLat := range []float64{-latOffset, latOffset} {
+		for _, dLng := range []float64{-lngOffset, lngOffset} {
+			tiles = append(tiles, Tile{
+				Center: Coordinates{Latitude: t.Center.Latitude + dLat, Longitude: t.Center.Longitude + dLng},
+				Radius: t.Radius / 2,
+			})
+		}
+	}
+	return tiles
+}
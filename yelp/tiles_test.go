@@ -0,0 +1,42 @@
+package yelp_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+var sanFrancisco = yelp.Bounds{
+	SouthWest: yelp.Coordinates{Latitude: 37.70, Longitude: -122.52},
+	NorthEast: yelp.Coordinates{Latitude: 37.81, Longitude: -122.36},
+}
+
+func TestCoverBoundsNonPositiveRadius(t *testing.T) {
+	for _, radius := range []float64{0, -500} {
+		done := make(chan []yelp.Tile, 1)
+		go func() { done <- yelp.CoverBounds(sanFrancisco, radius) }()
+		select {
+		case tiles := <-done:
+			if tiles != nil {
+				t.Errorf("CoverBounds(radius %v) = %d tiles, want nil", radius, len(tiles))
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("CoverBounds(radius %v) did not return", radius)
+		}
+	}
+}
+
+func TestTileSearchNonPositiveRadius(t *testing.T) {
+	c, err := yelp.New(nil, "test-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, radius := range []int64{0, -500} {
+		_, err := c.TileSearch(context.Background(), sanFrancisco, yelp.SearchOptions{Radius: yelp.Int64Ptr(radius)})
+		if err == nil {
+			t.Errorf("TileSearch(radius %d) succeeded, want an error", radius)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package yelp
+
+import (
+	"context"
+	"sort"
+)
+
+// BayesianRerank returns the businesses ordered by their Bayesian rating,
+// highest first: their rating pulled towards the mean rating of businesses
+// by as many virtual reviews as the median review count, so a 5 star
+// business with 3 reviews does not outrank a 4.5 star one with 800. Score is
+// the adjusted rating over 5 and the Explanation has its "rating" and
+// "prior" components.
+func BayesianRerank(businesses []Business) []RankedBusiness {
+	if len(businesses) == 0 {
+		return []RankedBusiness{}
+	}
+
+	var sumRatings float64
+	counts := make([]int64, len(businesses))
+	for i, b := range businesses {
+		sumRatings += b.Rating
+		counts[i] = b.ReviewCount
+	}
+	mean := sumRatings / float64(len(businesses))
+	sort.Slice(counts, func(i, j int) bool { return counts[i] < counts[j] })
+	prior := float64(counts[len(counts)/2])
+	if prior < 1 {
+		prior = 1
+	}
+
+	ranked := make([]RankedBusiness, len(businesses))
+	for i, b := range businesses {
+		total := prior + float64(b.ReviewCount)
+		components := []ScoreComponent{
+			{Name: "rating", Value: b.Rating / 5, Weight: float64(b.ReviewCount)},
+			{Name: "prior", Value: mean / 5, Weight: prior},
+		}
+		rb := RankedBusiness{Business: b}
+		for j := range components {
+			components[j].Contribution = components[j].Value * components[j].Weight / total
+			rb.Score += components[j].Contribution
+		}
+		rb.Explanation = Explanation{Components: components}
+		ranked[i] = rb
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+	return ranked
+}
+
+// TopRatedNearby returns the n best rated businesses of the category, an
+// alias such as "pizza", around the coordinates, by Bayesian rating, see
+// BayesianRerank. The candidates are the first page of the search, so n is
+// at most 50. category may be empty to look for any business.
+func (c *client) TopRatedNearby(ctx context.Context, lat, lng float64, category string, n int) ([]RankedBusiness, error) {
+	so := SearchOptions{
+		Coordinates: &Coordinates{Latitude: lat, Longitude: lng},
+		Limit:       Int64Ptr(maxSearchLimit),
+	}
+	if category != "" {
+		so.Categories = StringPtr(category)
+	}
+	sr, err := c.Search(ctx, so)
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := BayesianRerank(sr.Businesses)
+	if n >= 0 && n < len(ranked) {
+		ranked = ranked[:n]
+	}
+	return ranked, nil
+}
@@ -0,0 +1,190 @@
+package yelp
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// RequestTrace holds the timings of a request sent to Yelp, so latency can be
+// attributed to the network, to Yelp or to decoding. Phases skipped because
+// a connection was reused are zero.
+type RequestTrace struct {
+	Endpoint   Endpoint
+	Method     string
+	Host       string
+	StatusCode int
+	RequestID  string
+	Err        error
+
+	// ConnReused is true when the request was sent on an idle connection.
+	ConnReused bool
+
+	DNS     time.Duration
+	Connect time.Duration
+	TLS     time.Duration
+
+	// TTFB is the time from sending the request to its first response byte.
+	TTFB time.Duration
+	// Decode is the time taken to read and decode the response body.
+	Decode time.Duration
+	// Total is the time from sending the request to decoding its response.
+	Total time.Duration
+}
+
+// TraceFunc is called with the RequestTrace of every request sent.
+type TraceFunc func(context.Context, RequestTrace)
+
+// WithTrace makes the client call fn with the timings of every request it
+// sends, retries included. Requests served from the cache are not traced.
+func WithTrace(fn TraceFunc) Option {
+	return func(c *client) {
+		c.trace = fn
+	}
+}
+
+// requestTracer collects the timings of a request through httptrace.
+type requestTracer struct {
+	clock Clock
+
+	mu      sync.Mutex
+	t       RequestTrace
+	started time.Time
+
+	dnsStart, connectStart, tlsStart time.Time
+}
+
+// withTrace returns req with rt collecting its timings.
+func (rt *requestTracer) withTrace(req *http.Request) *http.Request {
+	ct := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			rt.mu.Lock()
+			rt.t.ConnReused = info.Reused
+			rt.mu.Unlock()
+		},
+		DNSStart: func(httptrace.DNSStartInfo) { rt.mark(&rt.dnsStart) },
+		DNSDone:  func(httptrace.DNSDoneInfo) { rt.since(rt.dnsStart, &rt.t.DNS) },
+		ConnectStart: func(string, string) {
+			rt.mark(&rt.connectStart)
+		},
+		ConnectDone: func(string, string, error) {
+			rt.since(rt.connectStart, &rt.t.Connect)
+		},
+		TLSHandshakeStart: func() { rt.mark(&rt.tlsStart) },
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			rt.since(rt.tlsStart, &rt.t.TLS)
+		},
+		GotFirstResponseByte: func() { rt.since(rt.started, &rt.t.TTFB) },
+	}
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), ct))
+}
+
+// mark sets at to the current time.
+func (rt *requestTracer) mark(at *time.Time) {
+	rt.mu.Lock()
+	*at = rt.clock.Now()
+	rt.mu.Unlock()
+}
+
+// since sets d to the time elapsed since start.
+func (rt *requestTracer) since(start time.Time, d *time.Duration) {
+	rt.mu.Lock()
+	*d = rt.clock.Now().Sub(start)
+	rt.mu.Unlock()
+}
+
+// startTrace returns a requestTracer for req, or nil when the client has no
+// TraceFunc.
+func (c *client) startTrace(req *http.Request, ep Endpoint) (*requestTracer, *http.Request) {
+	if c.trace == nil {
+		return nil, req
+	}
+	rt := &requestTracer{clock: c.clock, started: c.clock.Now()}
+	rt.t.Endpoint, rt.t.Method, rt.t.Host = ep, req.Method, req.URL.Host
+	return rt, rt.withTrace(req)
+}
+
+// finishTrace reports the timings of rt. decodeStart is the time the response
+// body started being read, zero when there is none.
+func (c *client) finishTrace(ctx context.Context, rt *requestTracer, resp *http.Response, err error, decodeStart time.Time) {
+	if rt == nil {
+		return
+	}
+	rt.mu.Lock()
+	t := rt.t
+	rt.mu.Unlock()
+
+	now := c.clock.Now()
+	t.Total = now.Sub(rt.started)
+	if !decodeStart.IsZero() {
+		t.Decode = now.Sub(decodeStart)
+	}
+	if resp != nil {
+		t.StatusCode = resp.StatusCode
+		t.RequestID = requestID(resp.Header)
+	}
+	t.Err = err
+	c.trace(ctx, t)
+}
+
+// HostStats holds the totals of the requests sent to a host.
+type HostStats struct {
+	Requests   int
+	ConnReused int
+	Errors     int
+
+	DNS     time.Duration
+	Connect time.Duration
+	TLS     time.Duration
+	TTFB    time.Duration
+	Decode  time.Duration
+	Total   time.Duration
+}
+
+// HostMetrics aggregates RequestTraces per host. Its Trace method is a
+// TraceFunc, e.g.
+//
+//	m := &yelp.HostMetrics{}
+//	c, err := yelp.New(nil, apiKey, yelp.WithTrace(m.Trace))
+type HostMetrics struct {
+	mu    sync.Mutex
+	hosts map[string]HostStats
+}
+
+// Trace adds t to the totals of its host.
+func (m *HostMetrics) Trace(_ context.Context, t RequestTrace) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.hosts == nil {
+		m.hosts = map[string]HostStats{}
+	}
+	s := m.hosts[t.Host]
+	s.Requests++
+	if t.ConnReused {
+		s.ConnReused++
+	}
+	if t.Err != nil {
+		s.Errors++
+	}
+	s.DNS += t.DNS
+	s.Connect += t.Connect
+	s.TLS += t.TLS
+	s.TTFB += t.TTFB
+	s.Decode += t.Decode
+	s.Total += t.Total
+	m.hosts[t.Host] = s
+}
+
+// Stats returns a copy of the totals by host.
+func (m *HostMetrics) Stats() map[string]HostStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stats := make(map[string]HostStats, len(m.hosts))
+	for h, s := range m.hosts {
+		stats[h] = s
+	}
+	return stats
+}
@@ -0,0 +1,129 @@
+package yelp
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+)
+
+// errCustomTransport is returned by New when a transport option is used with
+// an http.Client whose Transport is not an *http.Transport.
+var errCustomTransport = errors.New("yelp: transport options require the http.Client to use an *http.Transport")
+
+// WithProxy makes the client send its requests through the HTTP(S) proxy at
+// proxyURL, e.g. "http://proxy.corp:3128".
+func WithProxy(proxyURL string) Option {
+	return func(c *client) {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			c.setErr(err)
+			return
+		}
+		c.withTransport(func(t *http.Transport) {
+			t.Proxy = http.ProxyURL(u)
+		})
+	}
+}
+
+// ProxySelector returns the proxy to send a request through, or nil to send it
+// directly. HTTP, HTTPS and SOCKS5 proxy URLs are supported.
+type ProxySelector func(*http.Request) (*url.URL, error)
+
+// WithProxySelector makes the client call sel to pick the proxy of every
+// request it sends.
+func WithProxySelector(sel ProxySelector) Option {
+	return func(c *client) {
+		c.withTransport(func(t *http.Transport) {
+			t.Proxy = sel
+		})
+	}
+}
+
+// WithProxyRotation makes the client send its requests through the proxies at
+// proxyURLs in turn, e.g. "socks5://10.0.0.1:1080" and "http://10.0.0.2:3128".
+func WithProxyRotation(proxyURLs ...string) Option {
+	return func(c *client) {
+		sel, err := RoundRobinProxies(proxyURLs...)
+		if err != nil {
+			c.setErr(err)
+			return
+		}
+		WithProxySelector(sel)(c)
+	}
+}
+
+// RoundRobinProxies returns a ProxySelector picking the proxies at proxyURLs
+// in turn.
+func RoundRobinProxies(proxyURLs ...string) (ProxySelector, error) {
+	if len(proxyURLs) == 0 {
+		return nil, errors.New("yelp: no proxy to rotate")
+	}
+	proxies := make([]*url.URL, len(proxyURLs))
+	for i, p := range proxyURLs {
+		u, err := url.Parse(p)
+		if err != nil {
+			return nil, err
+		}
+		proxies[i] = u
+	}
+
+	var next uint64
+	return func(*http.Request) (*url.URL, error) {
+		i := atomic.AddUint64(&next, 1) - 1
+		return proxies[i%uint64(len(proxies))], nil
+	}, nil
+}
+
+// WithTLSConfig makes the client use a copy of cfg for its TLS connections.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(c *client) {
+		c.withTransport(func(t *http.Transport) {
+			t.TLSClientConfig = cfg.Clone()
+		})
+	}
+}
+
+// WithRootCAs makes the client verify the certificates of the servers with the
+// CAs in pool instead of the ones of the system, e.g. to trust the certificate
+// of a TLS intercepting proxy.
+func WithRootCAs(pool *x509.CertPool) Option {
+	return func(c *client) {
+		c.withTransport(func(t *http.Transport) {
+			if t.TLSClientConfig == nil {
+				t.TLSClientConfig = &tls.Config{}
+			}
+			t.TLSClientConfig.RootCAs = pool
+		})
+	}
+}
+
+// withTransport calls configure with the transport of the client. The first
+// call copies the http.Client and its transport so the ones given to New are
+// left untouched.
+func (c *client) withTransport(configure func(*http.Transport)) {
+	if c.transport == nil {
+		switch rt := c.Client.Transport.(type) {
+		case nil:
+			c.transport = http.DefaultTransport.(*http.Transport).Clone()
+		case *http.Transport:
+			c.transport = rt.Clone()
+		default:
+			c.setErr(errCustomTransport)
+			return
+		}
+		hc := *c.Client
+		hc.Transport = c.transport
+		c.Client = &hc
+	}
+	configure(c.transport)
+}
+
+// setErr records the first error of the options, returned by New.
+func (c *client) setErr(err error) {
+	if c.err == nil {
+		c.err = err
+	}
+}
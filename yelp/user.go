@@ -0,0 +1,67 @@
+package yelp
+
+import (
+	"net/url"
+	"path"
+	"strings"
+)
+
+// User is a Yelp user, such as the author of a review.
+type User struct {
+	ID         string `json:"id"`
+	ProfileURL string `json:"profile_url"`
+	ImageURL   string `json:"image_url"`
+	Name       string `json:"name"`
+}
+
+// ImageSize is a variant of the images served by the Yelp CDN, the name of
+// the file before its extension.
+type ImageSize string
+
+// Available image sizes. The square variants are cropped to the given number
+// of pixels.
+const (
+	ImageOriginal  ImageSize = "o"
+	ImageLarge     ImageSize = "l"
+	ImageSquare348 ImageSize = "348s"
+	ImageSquare258 ImageSize = "258s"
+	ImageSquare180 ImageSize = "180s"
+	ImageSquare120 ImageSize = "120s"
+	ImageSquare60  ImageSize = "60s"
+	ImageSquare30  ImageSize = "30s"
+)
+
+// ImageURLSize returns imageURL, a Yelp CDN image URL such as
+// https://s3-media2.fl.yelpcdn.com/photo/abc/o.jpg, for the image in the given
+// size. URLs of other hosts are returned unchanged.
+func ImageURLSize(imageURL string, size ImageSize) string {
+	u, err := url.Parse(imageURL)
+	if err != nil || !strings.HasSuffix(u.Hostname(), "yelpcdn.com") {
+		return imageURL
+	}
+	dir, file := path.Split(u.Path)
+	ext := path.Ext(file)
+	if dir == "" || ext == "" {
+		return imageURL
+	}
+	u.Path = dir + string(size) + ext
+	return u.String()
+}
+
+// ImageURLSize returns the profile image URL of the user in the given size,
+// or "" when the user has no profile image.
+func (u User) ImageURLSize(size ImageSize) string {
+	if u.ImageURL == "" {
+		return ""
+	}
+	return ImageURLSize(u.ImageURL, size)
+}
+
+// ImageURLSize returns the image URL of the business in the given size, or ""
+// when the business has no image.
+func (b Business) ImageURLSize(size ImageSize) string {
+	if b.ImageURL == "" {
+		return ""
+	}
+	return ImageURLSize(b.ImageURL, size)
+}
@@ -0,0 +1,96 @@
+package yelp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// KeyStatus is the outcome of ValidateAPIKey.
+type KeyStatus int
+
+// Possible outcomes of ValidateAPIKey.
+const (
+	// KeyValid means the API key is accepted by every endpoint checked.
+	KeyValid KeyStatus = iota
+	// KeyInvalid means the API key is missing, malformed or unknown to Yelp.
+	KeyInvalid
+	// KeyUnauthorized means the API key is valid but not allowed to use an
+	// endpoint checked.
+	KeyUnauthorized
+	// KeyRateLimited means the API key reached its rate limit, so it could
+	// not be checked completely.
+	KeyRateLimited
+	// KeyUnknown means the API key could not be checked, e.g. because Yelp
+	// was unreachable. The error of ValidateAPIKey tells why.
+	KeyUnknown
+)
+
+// String returns a description of the KeyStatus.
+func (ks KeyStatus) String() string {
+	switch ks {
+	case KeyValid:
+		return "valid"
+	case KeyInvalid:
+		return "invalid"
+	case KeyUnauthorized:
+		return "unauthorized"
+	case KeyRateLimited:
+		return "rate limited"
+	case KeyUnknown:
+		return "unknown"
+	}
+	return "unknown"
+}
+
+// ValidateAPIKey checks apiKey with a request to the categories and search
+// endpoints and reports whether it is valid, unauthorized for an endpoint or
+// rate limited, for setup wizards to give precise feedback. Missing and
+// malformed keys are invalid without a request. opts configure the client
+// making the requests. KeyUnknown is returned with the error when the requests
+// failed for another reason, such as a network error or a server error.
+func ValidateAPIKey(ctx context.Context, apiKey string, opts ...Option) (KeyStatus, error) {
+	cl, err := New(nil, apiKey, opts...)
+	if errors.Is(err, ErrMissingAPIKey) || errors.Is(err, ErrInvalidAPIKey) {
+		return KeyInvalid, nil
+	}
+	if err != nil {
+		return KeyUnknown, err
+	}
+	defer cl.Close(ctx)
+
+	probes := []func() error{
+		func() error {
+			_, err := cl.Ping(ctx)
+			return err
+		},
+		func() error {
+			_, err := cl.Search(ctx, SearchOptions{
+				Location: StringPtr("San Francisco, CA"),
+				Limit:    Int64Ptr(1),
+			})
+			return err
+		},
+	}
+	for _, probe := range probes {
+		err := probe()
+		if err == nil {
+			continue
+		}
+
+		apiErr := &APIError{}
+		if !errors.As(err, &apiErr) {
+			return KeyUnknown, err
+		}
+		switch apiErr.StatusCode {
+		case http.StatusUnauthorized:
+			return KeyInvalid, nil
+		case http.StatusForbidden:
+			return KeyUnauthorized, nil
+		case http.StatusTooManyRequests:
+			return KeyRateLimited, nil
+		}
+		return KeyUnknown, err
+	}
+	return KeyValid, nil
+}
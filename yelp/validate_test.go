@@ -0,0 +1,60 @@
+package yelp_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+func TestValidateAPIKeyTransportError(t *testing.T) {
+	// A listener closed right away gives an address refusing connections.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	host := "http://" + ln.Addr().String()
+	ln.Close()
+
+	status, err := yelp.ValidateAPIKey(context.Background(), "test-key", yelp.WithAPIHost(host))
+	if status != yelp.KeyUnknown || err == nil {
+		t.Fatalf("got %v, %v; want unknown with the network error", status, err)
+	}
+}
+
+func TestValidateAPIKeyStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		want   yelp.KeyStatus
+		err    bool
+	}{
+		{http.StatusOK, yelp.KeyValid, false},
+		{http.StatusUnauthorized, yelp.KeyInvalid, false},
+		{http.StatusForbidden, yelp.KeyUnauthorized, false},
+		{http.StatusTooManyRequests, yelp.KeyRateLimited, false},
+		{http.StatusBadGateway, yelp.KeyUnknown, true},
+	}
+	for _, tt := range tests {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(tt.status)
+			w.Write([]byte(`{}`))
+		}))
+		got, err := yelp.ValidateAPIKey(context.Background(), "test-key", yelp.WithAPIHost(srv.URL))
+		srv.Close()
+		if got != tt.want || (err != nil) != tt.err {
+			t.Errorf("status %d: got %v, %v; want %v", tt.status, got, err, tt.want)
+		}
+	}
+}
+
+func TestValidateAPIKeyMalformed(t *testing.T) {
+	for _, key := range []string{"", "not a key!"} {
+		if got, err := yelp.ValidateAPIKey(context.Background(), key); got != yelp.KeyInvalid || err != nil {
+			t.Errorf("key %q: got %v, %v; want invalid", key, got, err)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package yelp
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// defaultAPIVersion is the version of the Yelp Fusion API the types of the
+// package reflect.
+const defaultAPIVersion = "v3"
+
+// ResponseMapper rewrites the JSON body of a response of the endpoint family
+// ep into the layout of the v3 API, which the types of the package reflect.
+type ResponseMapper func(ep Endpoint, body []byte) ([]byte, error)
+
+// WithAPIVersion makes the client send its requests to version of the API,
+// e.g. "v4", instead of "v3". Response bodies are passed through mapper, if
+// not nil, before being decoded, so a newer version can be used before the
+// types of the package follow it:
+//
+//	yelp.WithAPIVersion("v4", func(ep yelp.Endpoint, body []byte) ([]byte, error) {
+//		if ep != yelp.EndpointSearch {
+//			return body, nil
+//		}
+//		return renameField(body, "results", "businesses")
+//	})
+func WithAPIVersion(version string, mapper ResponseMapper) Option {
+	return func(c *client) {
+		c.version = strings.Trim(version, "/")
+		c.mapper = mapper
+	}
+}
+
+// url returns the URL of the API path of the version of the client.
+func (c *client) url(path string) string {
+	return c.host + "/" + c.version + path
+}
+
+// decodeMapped decodes body into v once mapped by the ResponseMapper.
+func (c *client) decodeMapped(ep Endpoint, body io.Reader, v interface{}) error {
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	if b, err = c.mapper(ep, b); err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
@@ -0,0 +1,47 @@
+package yelp
+
+// vertical returns SearchOptions looking for the categories, sorted by best
+// match and with a full page of results.
+func vertical(categories string) SearchOptions {
+	return SearchOptions{
+		Categories: StringPtr(categories),
+		SortBy:     StringPtr("best_match"),
+		Limit:      Int64Ptr(maxSearchLimit),
+	}
+}
+
+// Restaurants returns SearchOptions looking for restaurants and food places.
+// Set Location or Coordinates before searching, e.g.
+//
+//	so := yelp.Restaurants()
+//	so.Location = yelp.StringPtr("San Francisco")
+func Restaurants() SearchOptions {
+	return vertical("restaurants,food")
+}
+
+// Bars returns SearchOptions looking for bars, pubs and breweries.
+func Bars() SearchOptions {
+	return vertical("bars,pubs,breweries")
+}
+
+// Coffee returns SearchOptions looking for coffee shops and cafes.
+func Coffee() SearchOptions {
+	return vertical("coffee,cafes")
+}
+
+// Nightlife returns SearchOptions looking for nightlife venues open now.
+func Nightlife() SearchOptions {
+	so := vertical("nightlife")
+	so.OpenNow = BoolPtr(true)
+	return so
+}
+
+// Hotels returns SearchOptions looking for hotels and bed and breakfasts.
+func Hotels() SearchOptions {
+	return vertical("hotels,bedbreakfast")
+}
+
+// Gyms returns SearchOptions looking for gyms and fitness studios.
+func Gyms() SearchOptions {
+	return vertical("gyms,fitness")
+}
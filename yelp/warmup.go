@@ -0,0 +1,104 @@
+package yelp
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// warmUpConns is the number of connections opened by WarmUp, enough for the
+// batch calls to start on warm connections.
+const warmUpConns = batchWorkers
+
+// WithHTTP2 enables or disables HTTP/2 for the connections of the client.
+// HTTP/2 multiplexes the requests on a single connection, which keeps a
+// warmed up connection in use.
+func WithHTTP2(enabled bool) Option {
+	return func(c *client) {
+		c.withTransport(func(t *http.Transport) {
+			t.ForceAttemptHTTP2 = enabled
+			if !enabled {
+				t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+			}
+		})
+	}
+}
+
+// WarmUp opens connections to the API host ahead of the first request, so it
+// does not pay for the DNS lookup and the TCP and TLS handshakes, e.g. in the
+// init phase of a serverless function. The connections are kept idle by the
+// transport of the http.Client, so no more are opened than it keeps idle per
+// host, see WithServerless to raise the limit. WarmUp sends unauthenticated
+// HEAD requests, which do not count against the daily quota. The error is the
+// one of the first request that failed.
+func (c *client) WarmUp(ctx context.Context) error {
+	done, err := c.track()
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	ctx, cancel := c.withShutdown(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errs := make([]error, c.warmUpConnCount())
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = c.warmUpConn(ctx)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// warmUpConnCount returns the number of connections WarmUp opens: warmUpConns,
+// or fewer when the transport would close the extra ones once idle.
+func (c *client) warmUpConnCount() int {
+	t, ok := c.Client.Transport.(*http.Transport)
+	if c.Client.Transport == nil {
+		t, ok = http.DefaultTransport.(*http.Transport)
+	}
+	if !ok {
+		return warmUpConns
+	}
+	idle := t.MaxIdleConnsPerHost
+	if idle <= 0 {
+		idle = http.DefaultMaxIdleConnsPerHost
+	}
+	if idle < warmUpConns {
+		return idle
+	}
+	return warmUpConns
+}
+
+// warmUpConn sends a HEAD request to the API host and drains the response so
+// its connection goes back to the idle pool.
+func (c *client) warmUpConn(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.host+"/", nil)
+	if err != nil {
+		return err
+	}
+	release, err := c.acquireSlot(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.Body.Close()
+}
@@ -0,0 +1,135 @@
+package yelp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// yamlLine is a meaningful line of a YAML document.
+type yamlLine struct {
+	num    int
+	indent int
+	text   string
+}
+
+// parseYAML parses the subset of YAML used by configuration files: nested
+// mappings, block and flow sequences of scalars, scalars and comments.
+func parseYAML(data []byte) (map[string]interface{}, error) {
+	lines := []yamlLine{}
+	for i, l := range strings.Split(string(data), "\n") {
+		l = strings.TrimRight(stripYAMLComment(l), " \t\r")
+		text := strings.TrimLeft(l, " ")
+		if text == "" || text == "---" {
+			continue
+		}
+		lines = append(lines, yamlLine{num: i + 1, indent: len(l) - len(text), text: text})
+	}
+
+	m, rest, err := parseYAMLMapping(lines, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("yaml: line %d: unexpected indentation", rest[0].num)
+	}
+	return m, nil
+}
+
+// parseYAMLMapping parses the mapping at the given indentation and returns the
+// lines after it.
+func parseYAMLMapping(lines []yamlLine, indent int) (map[string]interface{}, []yamlLine, error) {
+	m := map[string]interface{}{}
+	for len(lines) > 0 && lines[0].indent == indent {
+		l := lines[0]
+		lines = lines[1:]
+
+		i := strings.Index(l.text, ":")
+		if i <= 0 || strings.HasPrefix(l.text, "- ") {
+			return nil, nil, fmt.Errorf("yaml: line %d: expected a key", l.num)
+		}
+		key, val := strings.TrimSpace(l.text[:i]), strings.TrimSpace(l.text[i+1:])
+		if val != "" {
+			m[key] = parseYAMLScalar(val)
+			continue
+		}
+
+		switch {
+		case len(lines) > 0 && lines[0].indent >= indent && strings.HasPrefix(lines[0].text, "- "):
+			seq := []interface{}{}
+			seqIndent := lines[0].indent
+			for len(lines) > 0 && lines[0].indent == seqIndent && strings.HasPrefix(lines[0].text, "- ") {
+				seq = append(seq, parseYAMLScalar(strings.TrimSpace(lines[0].text[2:])))
+				lines = lines[1:]
+			}
+			m[key] = seq
+		case len(lines) > 0 && lines[0].indent > indent:
+			child, rest, err := parseYAMLMapping(lines, lines[0].indent)
+			if err != nil {
+				return nil, nil, err
+			}
+			m[key], lines = child, rest
+		default:
+			m[key] = nil
+		}
+	}
+	if len(lines) > 0 && lines[0].indent > indent {
+		return nil, nil, fmt.Errorf("yaml: line %d: unexpected indentation", lines[0].num)
+	}
+	return m, lines, nil
+}
+
+// parseYAMLScalar returns the value of a scalar or flow sequence.
+func parseYAMLScalar(s string) interface{} {
+	if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") {
+		seq := []interface{}{}
+		for _, item := range strings.Split(s[1:len(s)-1], ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				seq = append(seq, parseYAMLScalar(item))
+			}
+		}
+		return seq
+	}
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		if s[0] == '"' {
+			if unquoted, err := strconv.Unquote(s); err == nil {
+				return unquoted
+			}
+		}
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'")
+	}
+
+	switch s {
+	case "~", "null":
+		return nil
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f
+	}
+	return s
+}
+
+// stripYAMLComment removes the comment of a line, ignoring "#" in quotes.
+func stripYAMLComment(l string) string {
+	var quote byte
+	for i := 0; i < len(l); i++ {
+		switch c := l[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || l[i-1] == ' ' || l[i-1] == '\t'):
+			return l[:i]
+		}
+	}
+	return l
+}
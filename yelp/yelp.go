@@ -1,109 +1,487 @@
 package yelp
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
+	"time"
 )
 
 const (
-	// apiHost is the base URL for the Yelp API
+	// apiHost is the default base URL for the Yelp API
 	apiHost = "https://api.yelp.com"
 
-	// searchPath is the path to search for businesses
-	searchPath = "/v3/businesses/search"
+	// searchPath is the path to search for businesses, relative to the API
+	// version like the other paths
+	searchPath = "/businesses/search"
+
+	// phoneSearchPath is the path to search for businesses by phone number
+	phoneSearchPath = "/businesses/search/phone"
 
 	// businessPath is the path to get a business by its id
-	businessPath = "/v3/businesses/%s"
+	businessPath = "/businesses/%s"
+
+	// reviewsPath is the path to get the reviews of a business by its id
+	reviewsPath = "/businesses/%s/reviews"
+
+	// eventsPath is the path to search for events
+	eventsPath = "/events"
+
+	// defaultTimeout is the timeout of the http.Client made when none is given
+	defaultTimeout = 30 * time.Second
+)
+
+var (
+	// ErrMissingAPIKey is returned by New when the API key is empty.
+	ErrMissingAPIKey = errors.New("yelp: missing API key")
+
+	// ErrInvalidAPIKey is returned by New when the API key is malformed.
+	ErrInvalidAPIKey = errors.New("yelp: malformed API key")
 )
 
 // Client defines the current available Yelp API requests that can be made.
 type Client interface {
-	Search(SearchOptions) (SearchResults, error)
-	BusinessByID(businessID string) (Business, error)
+	Search(context.Context, SearchOptions) (SearchResults, error)
+	SearchByPhone(ctx context.Context, phone string) (SearchResults, error)
+	BusinessByID(ctx context.Context, businessID string, bo BusinessOptions) (Business, error)
+	BusinessesByIDs(ctx context.Context, ids []string, bo BusinessOptions) ([]Business, error)
+	Reviews(ctx context.Context, businessID string, ro ReviewsOptions) (ReviewsResults, error)
+	SearchEvents(context.Context, EventsOptions) (EventsResults, error)
+	BusinessMatch(context.Context, MatchOptions) ([]Business, error)
+	BulkMatch(ctx context.Context, inputs []MatchInput, minConfidence float64) []MatchResult
+	Competitors(ctx context.Context, businessID string, radiusMeters int) ([]Business, error)
+	TopRatedNearby(ctx context.Context, lat, lng float64, category string, n int) ([]RankedBusiness, error)
+	ResolveMerge(ctx context.Context, oldID string, stored Business) (MergeResolution, error)
+	BrandLocations(ctx context.Context, name string, region string) ([]Business, error)
+	TileSearch(ctx context.Context, bounds Bounds, so SearchOptions) ([]Business, error)
+	SearchAlongRoute(ctx context.Context, polyline []Coordinates, corridorMeters int, so SearchOptions) ([]RouteResult, error)
+	RingSearch(ctx context.Context, center Coordinates, so SearchOptions, radii ...int) ([]Ring, error)
+	SearchCities(ctx context.Context, cities []string, so SearchOptions) (map[string]SearchResults, error)
+	AreaStats(ctx context.Context, so SearchOptions) (AreaStats, error)
+	Ping(context.Context) (PingResult, error)
+	WarmUp(context.Context) error
+	Close(context.Context) error
 }
 
 // client implements the Client interface.
 type client struct {
 	*http.Client
-	apiKey string
+	apiKey    string
+	host      string
+	version   string
+	mapper    ResponseMapper
+	transport *http.Transport
+	err       error
+
+	mu       sync.Mutex
+	closed   bool
+	inflight sync.WaitGroup
+	shutdown context.Context
+	stop     context.CancelFunc
+
+	translator  Translator
+	translateTo string
+
+	defaultPolicy Policy
+	policies      map[Endpoint]Policy
+
+	scheduler  *Scheduler
+	quota      *QuotaManager
+	politeness *politeness
+	hedger     *hedger
+	slots      chan struct{}
+
+	dryRun bool
+	har    *HARRecorder
+
+	locale          *string
+	fallbackLocales []string
+	acceptLanguage  *string
+	searchRadius    *int64
+
+	cache          Cache
+	cacheTTL       time.Duration
+	cacheEndpoints map[Endpoint]bool
+	staleWindow    time.Duration
+	staleIfErr     bool
+	staleMaxAge    time.Duration
+	notFound       *notFoundCache
+	onChange       func(key string, stale, fresh []byte)
+	refreshing     map[string]bool
+
+	geocoder Geocoder
+
+	logger *slog.Logger
+	clock  Clock
+	trace  TraceFunc
+	hooks  []RequestHook
+	drift  *driftDetector
+
+	deterministic bool
+	serverless    bool
+}
+
+// Option configures optional behavior of a client.
+type Option func(*client)
+
+// WithAPIHost makes the client send its requests to host instead of
+// https://api.yelp.com, e.g. to go through a gateway.
+func WithAPIHost(host string) Option {
+	return func(c *client) {
+		c.host = strings.TrimSuffix(host, "/")
+	}
+}
+
+// WithTranslator makes the client translate review excerpts to the target
+// language, an ISO 639-1 code such as "en", using t.
+func WithTranslator(t Translator, target string) Option {
+	return func(c *client) {
+		c.translator = t
+		c.translateTo = target
+	}
+}
+
+// New returns a new Yelp client. A http.Client with a default timeout is used
+// when c is nil. An error is returned when the API key is empty or malformed
+// or an option cannot be applied.
+func New(c *http.Client, apiKey string, opts ...Option) (Client, error) {
+	if err := checkAPIKey(apiKey); err != nil {
+		return nil, err
+	}
+	if c == nil {
+		c = &http.Client{Timeout: defaultTimeout}
+	}
+
+	cl := &client{
+		Client:  c,
+		apiKey:  apiKey,
+		host:    apiHost,
+		version: defaultAPIVersion,
+		clock:   systemClock{},
+	}
+	cl.shutdown, cl.stop = context.WithCancel(context.Background())
+	for _, opt := range opts {
+		opt(cl)
+	}
+	if cl.err != nil {
+		cl.stop()
+		return nil, cl.err
+	}
+	return cl, nil
 }
 
-// New returns a new Yelp client.
-func New(c *http.Client, apiKey string) *client {
-	return &client{
-		Client: c,
-		apiKey: apiKey,
+// checkAPIKey returns an error when the API key is empty or has characters
+// Yelp never uses in API keys.
+func checkAPIKey(apiKey string) error {
+	if apiKey == "" {
+		return ErrMissingAPIKey
+	}
+	for _, r := range apiKey {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+		default:
+			return ErrInvalidAPIKey
+		}
 	}
+	return nil
 }
 
 // Search makes a request given the options passed in.
-func (c *client) Search(so SearchOptions) (SearchResults, error) {
+func (c *client) Search(ctx context.Context, so SearchOptions) (SearchResults, error) {
 	respBody := SearchResults{}
+	so, err := c.prepareSearch(ctx, so)
+	if err != nil {
+		return respBody, err
+	}
+	err = c.withLocales(c.localeOr(so.Locale), func(locale *string) (bool, error) {
+		so.Locale = locale
+		respBody = SearchResults{Locale: StringVal(locale)}
+		urlStr := c.url(searchPath) + "?" + so.URLValues().Encode()
+		_, err := c.authedDo(ctx, EndpointSearch, "GET", urlStr, nil, c.localeHeaders(ctx, locale), &respBody)
+		return respBody.localized(), err
+	})
+	return respBody, err
+}
+
+// prepareSearch validates so and fills in the defaults of the client.
+func (c *client) prepareSearch(ctx context.Context, so SearchOptions) (SearchOptions, error) {
 	if !so.IsValid() {
-		return respBody, errors.New("SearchOptions provided is not valid. Please see yelp/search.go for more details.")
+		return so, errors.New("SearchOptions provided is not valid. Please see yelp/search.go for more details.")
+	}
+	if so.Radius == nil {
+		so.Radius = c.searchRadius
+	}
+	return c.geocode(ctx, so)
+}
+
+// SearchByPhone looks for businesses by phone number. The phone is normalized
+// to E.164 before making the request, see NormalizePhone.
+func (c *client) SearchByPhone(ctx context.Context, phone string) (SearchResults, error) {
+	respBody := SearchResults{}
+	phone, err := NormalizePhone(phone)
+	if err != nil {
+		return respBody, err
 	}
 
-	urlStr := apiHost + searchPath + "?" + so.URLValues().Encode()
-	_, err := c.authedDo("GET", urlStr, nil, nil, &respBody)
+	urlStr := c.url(phoneSearchPath) + "?" + url.Values{"phone": {phone}}.Encode()
+	_, err = c.authedDo(ctx, EndpointSearch, "GET", urlStr, nil, nil, &respBody)
 	return respBody, err
 }
 
 // BusinessByID looks for a business information by its id.
-func (c *client) BusinessByID(businessID string) (Business, error) {
+func (c *client) BusinessByID(ctx context.Context, businessID string, bo BusinessOptions) (Business, error) {
 	respBody := Business{}
 
-	urlStr := apiHost + fmt.Sprintf(businessPath, businessID)
-	_, err := c.authedDo("GET", urlStr, nil, nil, &respBody)
+	err := c.withLocales(c.localeOr(bo.Locale), func(locale *string) (bool, error) {
+		bo.Locale = locale
+		respBody = Business{Locale: StringVal(locale)}
+		urlStr := c.url(fmt.Sprintf(businessPath, url.PathEscape(businessID)))
+		if vals := bo.URLValues(); len(vals) > 0 {
+			urlStr += "?" + vals.Encode()
+		}
+		_, err := c.authedDo(ctx, EndpointBusiness, "GET", urlStr, nil, c.localeHeaders(ctx, locale), &respBody)
+		return respBody.localized(), err
+	})
 	return respBody, err
 }
 
-// authedDo fetches the access token again if it is expired and constructs a
-// request with the Authorization Header set with the access token. The response
-// body is decoded into v.
-func (c *client) authedDo(method string, url string, body io.Reader, headers map[string]string, v interface{}) (*http.Response, error) {
-	req, err := http.NewRequest(method, url, body)
+// Reviews looks for the review excerpts of a business by its id. When a
+// Translator is configured the excerpts are translated before returning.
+func (c *client) Reviews(ctx context.Context, businessID string, ro ReviewsOptions) (ReviewsResults, error) {
+	respBody := ReviewsResults{}
+	ro.Locale = c.localeOr(ro.Locale)
+
+	urlStr := c.url(fmt.Sprintf(reviewsPath, url.PathEscape(businessID)))
+	if vals := ro.URLValues(); len(vals) > 0 {
+		urlStr += "?" + vals.Encode()
+	}
+	_, err := c.authedDo(ctx, EndpointReviews, "GET", urlStr, nil, c.localeHeaders(ctx, ro.Locale), &respBody)
+	if err != nil || c.translator == nil {
+		return respBody, err
+	}
+
+	err = respBody.translate(c.translator, localeLanguage(StringVal(ro.Locale)), c.translateTo)
+	return respBody, err
+}
+
+// SearchEvents makes a request to the Events API given the options passed in.
+func (c *client) SearchEvents(ctx context.Context, eo EventsOptions) (EventsResults, error) {
+	respBody := EventsResults{}
+	if !eo.IsValid() {
+		return respBody, errors.New("EventsOptions provided is not valid. Please see yelp/events.go for more details.")
+	}
+
+	eo.Locale = c.localeOr(eo.Locale)
+	urlStr := c.url(eventsPath) + "?" + eo.URLValues().Encode()
+	_, err := c.authedDo(ctx, EndpointEvents, "GET", urlStr, nil, c.localeHeaders(ctx, eo.Locale), &respBody)
+	return respBody, err
+}
+
+// BusinessMatch looks for the businesses matching a name and an address, e.g.
+// to find the Yelp listing of a known place.
+func (c *client) BusinessMatch(ctx context.Context, mo MatchOptions) ([]Business, error) {
+	respBody := matchResults{}
+	if !mo.IsValid() {
+		return nil, errors.New("MatchOptions provided is not valid. Please see yelp/businessmatch.go for more details.")
+	}
+
+	urlStr := c.url(matchPath) + "?" + mo.URLValues().Encode()
+	_, err := c.authedDo(ctx, EndpointBusiness, "GET", urlStr, nil, nil, &respBody)
+	return respBody.Businesses, err
+}
+
+// authedDo makes a request with the Authorization Header set with the API key
+// and the headers of ctx, see WithHeaders, from the cache when the endpoint
+// family is cached. Remembered not found errors are returned without sending
+// the request, see WithNotFoundCache. The response body is decoded into v.
+// The body is kept as bytes so the request can be rebuilt and sent again.
+func (c *client) authedDo(ctx context.Context, ep Endpoint, method string, url string, body []byte, headers map[string]string, v interface{}) (*http.Response, error) {
+	done, err := c.track()
 	if err != nil {
 		return nil, err
 	}
+	defer done()
 
-	for key, val := range headers {
-		req.Header.Set(key, val)
+	ctx, cancel := c.withShutdown(ctx)
+	defer cancel()
+
+	headers = withContextHeaders(ctx, headers)
+	if c.notFound != nil && method == "GET" && !c.dryRun {
+		var resp *http.Response
+		err := c.notFoundDo(ctx, ep, url, func() (err error) {
+			resp, err = c.lookup(ctx, ep, method, url, body, headers, v)
+			return err
+		})
+		return resp, err
 	}
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	return c.lookup(ctx, ep, method, url, body, headers, v)
+}
+
+// lookup makes the request from the cache when its endpoint family is cached,
+// and fetches it otherwise.
+func (c *client) lookup(ctx context.Context, ep Endpoint, method string, url string, body []byte, headers map[string]string, v interface{}) (*http.Response, error) {
+	if c.cached(ep, method) {
+		return c.cachedDo(ctx, ep, url, headers, v)
+	}
+	return c.fetch(ctx, ep, method, url, body, headers, v)
+}
+
+// fetch constructs the request and sends it following the Policy of the
+// endpoint family, retrying it when it may succeed.
+func (c *client) fetch(ctx context.Context, ep Endpoint, method string, url string, body []byte, headers map[string]string, v interface{}) (*http.Response, error) {
+	p := c.policy(ep)
+	if p.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.Timeout)
+		defer cancel()
+	}
+
+	for retry := 0; ; retry++ {
+		req, err := c.newRequest(method, url, body, headers)
+		if err != nil {
+			return nil, err
+		}
+		req = req.WithContext(ctx)
+		if err := c.runHooks(req); err != nil {
+			return nil, err
+		}
+		if c.dryRun {
+			return nil, newDryRunError(req)
+		}
+
+		resp, err := c.sendOnce(req, ep, v)
+		if retry >= p.MaxRetries || !shouldRetry(err) || ctx.Err() != nil {
+			return resp, err
+		}
 
-	resp, err := c.Do(req)
+		wait := p.backoff(retry + 1)
+		c.log(ctx, slog.LevelInfo, "yelp: retrying request", "endpoint", ep, "retry", retry+1, "wait", wait, "error", err)
+		timer := c.clock.NewTimer(wait)
+		select {
+		case <-timer.C():
+		case <-ctx.Done():
+			timer.Stop()
+			return resp, err
+		}
+	}
+}
+
+// sendOnce sends req, hedged when hedging is enabled.
+func (c *client) sendOnce(req *http.Request, ep Endpoint, v interface{}) (*http.Response, error) {
+	if c.hedger != nil {
+		return c.sendHedged(req, ep, v)
+	}
+	return c.send(req, ep, v)
+}
+
+// send waits for the turn of req when politeness is enabled, charges it to
+// the QuotaManager and sends it through the Scheduler, if any, and decodes
+// the response body into v.
+func (c *client) send(req *http.Request, ep Endpoint, v interface{}) (*http.Response, error) {
+	ctx := req.Context()
+	if err := c.waitPolitely(ctx, ep); err != nil {
+		return nil, err
+	}
+	if c.quota != nil {
+		if err := c.quota.charge(ctx, c.clock.Now()); err != nil {
+			if errors.Is(err, ErrQuotaExceeded) {
+				c.log(ctx, slog.LevelInfo, "yelp: quota exceeded", "endpoint", ep, "tenant", TenantFromContext(ctx))
+			}
+			return nil, err
+		}
+	}
+	if c.scheduler == nil {
+		return c.do(req, ep, v)
+	}
+
+	started := c.clock.Now()
+	release, err := c.scheduler.acquire(ctx)
 	if err != nil {
+		return nil, err
+	}
+	defer release()
+	c.log(ctx, slog.LevelDebug, "yelp: scheduled request", "endpoint", ep, "tenant", TenantFromContext(ctx), "wait", c.clock.Now().Sub(started))
+	return c.do(req, ep, v)
+}
+
+// do sends req and decodes the response body into v. The exchange is recorded
+// when a HARRecorder is configured, traced when a TraceFunc is and checked for
+// schema drift when sampled.
+func (c *client) do(req *http.Request, ep Endpoint, v interface{}) (resp *http.Response, err error) {
+	var decodeStart time.Time
+	rt, req := c.startTrace(req, ep)
+	defer func() { c.finishTrace(req.Context(), rt, resp, err, decodeStart) }()
+
+	release, err := c.acquireSlot(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	started := c.clock.Now()
+	resp, err = c.Do(req)
+	if err != nil {
+		if c.har != nil {
+			c.har.record(req, nil, nil, err, started, c.clock.Now().Sub(started))
+		}
 		return resp, err
 	}
 
 	defer resp.Body.Close()
+	decodeStart = c.clock.Now()
+
+	var body io.Reader = resp.Body
+	checkDrift := resp.StatusCode == 200 && c.drift.sample()
+	if c.har != nil || checkDrift {
+		b, err := io.ReadAll(resp.Body)
+		if c.har != nil {
+			c.har.record(req, resp, b, err, started, c.clock.Now().Sub(started))
+		}
+		if err != nil {
+			return resp, err
+		}
+		if checkDrift {
+			c.drift.check(ep, req.URL.Path, b, v)
+		}
+		body = bytes.NewReader(b)
+	}
 
 	if resp.StatusCode != 200 {
-		return resp, fmt.Errorf("Yelp request failed with status %s", resp.Status)
+		b, _ := io.ReadAll(body)
+		return resp, newAPIError(resp, b)
 	}
 
-	err = json.NewDecoder(resp.Body).Decode(v)
+	if c.mapper != nil {
+		return resp, c.decodeMapped(ep, body, v)
+	}
+	err = json.NewDecoder(body).Decode(v)
 	return resp, err
 }
 
-// postForm makes a POST request with form values and decodes the response body
-// into v.
-func (c *client) postForm(url string, data url.Values, v interface{}) (*http.Response, error) {
-	resp, err := c.PostForm(url, data)
+// newRequest constructs a request with the Authorization Header set. Every call
+// returns a request with a fresh body reader, and the request's GetBody is set
+// so it can be replayed.
+func (c *client) newRequest(method string, url string, body []byte, headers map[string]string) (*http.Request, error) {
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, url, r)
 	if err != nil {
-		return resp, err
+		return nil, err
 	}
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			log.Print(err)
-		}
-	}()
 
-	err = json.NewDecoder(resp.Body).Decode(v)
-	return resp, err
+	for key, val := range headers {
+		req.Header.Set(key, val)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	return req, nil
 }
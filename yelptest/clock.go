@@ -0,0 +1,98 @@
+package yelptest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ivancevich/go-yelp/yelp"
+)
+
+// Clock is a yelp.Clock that only moves when told to, so tests of retries
+// and cache expiry do not sleep. Timers fire when Advance moves the time past
+// them; Sleep advances the clock.
+type Clock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*timer
+}
+
+// NewClock returns a Clock set to now.
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+// Now returns the time of the clock.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Sleep advances the clock by d.
+func (c *Clock) Sleep(d time.Duration) {
+	c.Advance(d)
+}
+
+// NewTimer returns a timer firing once the clock is advanced by d.
+func (c *Clock) NewTimer(d time.Duration) yelp.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &timer{clock: c, at: c.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.c <- c.now
+		return t
+	}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+// Advance moves the clock forward by d, firing the timers due.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if t.at.After(c.now) {
+			pending = append(pending, t)
+			continue
+		}
+		t.c <- c.now
+	}
+	c.timers = pending
+}
+
+// Timers returns the number of timers waiting to fire, so a test can wait
+// for the code under test to start waiting before advancing the clock.
+func (c *Clock) Timers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+// timer is a timer of a Clock.
+type timer struct {
+	clock *Clock
+	at    time.Time
+	c     chan time.Time
+}
+
+// C returns the channel the time is sent on when the timer fires.
+func (t *timer) C() <-chan time.Time {
+	return t.c
+}
+
+// Stop removes the timer from its clock, returning false when it already
+// fired.
+func (t *timer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	for i, pending := range t.clock.timers {
+		if pending == t {
+			t.clock.timers = append(t.clock.timers[:i], t.clock.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
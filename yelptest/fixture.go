@@ -0,0 +1,138 @@
+package yelptest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Fixture is a recorded response.
+type Fixture struct {
+	Method string          `json:"method"`
+	URL    string          `json:"url"`
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body"`
+}
+
+// fixturePath returns the path of the fixture of req in dir, named after the
+// request path and a hash of the method, path and sorted query.
+func fixturePath(dir string, req *http.Request) string {
+	key := req.Method + " " + req.URL.Path + "?" + req.URL.Query().Encode()
+	sum := sha256.Sum256([]byte(key))
+
+	name := strings.Trim(strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-':
+			return r
+		}
+		return '_'
+	}, req.URL.Path), "_")
+	return filepath.Join(dir, name+"-"+hex.EncodeToString(sum[:6])+".json")
+}
+
+// Replayer is an http.RoundTripper answering requests with the fixtures in
+// Dir. Requests without a fixture fail.
+type Replayer struct {
+	Dir string
+}
+
+// RoundTrip returns the recorded response of req.
+func (rp *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := fixturePath(rp.Dir, req)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("yelptest: no fixture for %s %s: %w", req.Method, req.URL, err)
+	}
+	f := Fixture{}
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("yelptest: reading %s: %w", path, err)
+	}
+	return response(req, f.Status, f.Body), nil
+}
+
+// Recorder is an http.RoundTripper sending requests to the live API through
+// Transport, or http.DefaultTransport when nil, and saving the responses as
+// fixtures in Dir. Secrets, such as the API key, are replaced in the saved
+// bodies and URLs.
+type Recorder struct {
+	Dir       string
+	Secrets   []string
+	Transport http.RoundTripper
+}
+
+// RoundTrip sends req and records its response.
+func (rc *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt := rc.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	f := Fixture{
+		Method: req.Method,
+		URL:    rc.sanitize(req.URL.String()),
+		Status: resp.StatusCode,
+		Body:   json.RawMessage(rc.sanitize(string(body))),
+	}
+	if !json.Valid(f.Body) {
+		f.Body, _ = json.Marshal(string(f.Body))
+	}
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFile(fixturePath(rc.Dir, req), append(data, '\n')); err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// sanitize replaces the secrets in s.
+func (rc *Recorder) sanitize(s string) string {
+	for _, secret := range rc.Secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, "REDACTED")
+		}
+	}
+	return s
+}
+
+// response returns a response to req with status and body.
+func response(req *http.Request, status int, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// writeFile writes data to path, creating its directory.
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,179 @@
+package yelptest_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ivancevich/go-yelp/yelp"
+	"github.com/ivancevich/go-yelp/yelptest"
+)
+
+const (
+	fixtures  = "testdata/fixtures"
+	garyDanko = "WavvLdfdP6g8aZTtbBQHTw"
+)
+
+func TestSearch(t *testing.T) {
+	c := yelptest.NewClient(t, fixtures)
+	term, location := "restaurants", "San Francisco"
+	sr, err := c.Search(context.Background(), yelp.SearchOptions{Term: &term, Location: &location})
+	if err != nil {
+		t.Fatal(err)
+	}
+	yelptest.Golden(t, "testdata/golden/search.json", sr)
+}
+
+func TestBusinessByID(t *testing.T) {
+	c := yelptest.NewClient(t, fixtures)
+	b, err := c.BusinessByID(context.Background(), garyDanko, yelp.BusinessOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	yelptest.Golden(t, "testdata/golden/business.json", b)
+}
+
+func TestBusinessNotFound(t *testing.T) {
+	c := yelptest.NewClient(t, fixtures)
+	_, err := c.BusinessByID(context.Background(), "closed-for-good", yelp.BusinessOptions{})
+	if class := yelp.ClassifyError(err); class != yelp.ErrorClassNotFound {
+		t.Fatalf("got %v (%s), want a not found error", err, class)
+	}
+}
+
+func TestReplayerWithoutFixture(t *testing.T) {
+	c := yelptest.NewClient(t, fixtures)
+	_, err := c.BusinessByID(context.Background(), "never-recorded", yelp.BusinessOptions{})
+	if err == nil || !strings.Contains(err.Error(), "no fixture") {
+		t.Fatalf("got %v, want a missing fixture error", err)
+	}
+}
+
+// countingTransport counts the requests sent through rt.
+type countingTransport struct {
+	rt http.RoundTripper
+	n  atomic.Int32
+}
+
+func (ct *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ct.n.Add(1)
+	return ct.rt.RoundTrip(req)
+}
+
+func TestCacheExpiresWithClock(t *testing.T) {
+	clock := yelptest.NewClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	ct := &countingTransport{rt: &yelptest.Replayer{Dir: fixtures}}
+	c, err := yelp.New(&http.Client{Transport: ct}, "fixture-key",
+		yelp.WithClock(clock), yelp.WithCache(yelp.NewMemoryCache(), time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	for _, advance := range []time.Duration{0, 30 * time.Minute, 31 * time.Minute} {
+		clock.Advance(advance)
+		if _, err := c.BusinessByID(ctx, garyDanko, yelp.BusinessOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := ct.n.Load(); n != 2 {
+		t.Fatalf("sent %d requests, want 2: one cached, one after expiry", n)
+	}
+}
+
+func TestClockTimers(t *testing.T) {
+	clock := yelptest.NewClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	fired := clock.NewTimer(time.Second)
+	stopped := clock.NewTimer(time.Second)
+	later := clock.NewTimer(time.Minute)
+	if !stopped.Stop() {
+		t.Fatal("Stop of a pending timer returned false")
+	}
+
+	clock.Advance(time.Second)
+	select {
+	case <-fired.C():
+	default:
+		t.Fatal("timer due did not fire")
+	}
+	select {
+	case <-stopped.C():
+		t.Fatal("stopped timer fired")
+	case <-later.C():
+		t.Fatal("timer fired early")
+	default:
+	}
+	if n := clock.Timers(); n != 1 {
+		t.Fatalf("%d timers pending, want 1", n)
+	}
+
+	clock.Sleep(time.Minute)
+	select {
+	case <-later.C():
+	default:
+		t.Fatal("Sleep did not fire the timer")
+	}
+	if fired.Stop() {
+		t.Fatal("Stop of a fired timer returned true")
+	}
+}
+
+// roundTripFunc is an http.RoundTripper calling itself.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRecorderRedactsSecrets(t *testing.T) {
+	dir := t.TempDir()
+	live := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if got := req.Header.Get("Authorization"); got != "Bearer live-key" {
+			t.Errorf("Authorization %q, want the live key", got)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(`{"id":"` + garyDanko + `","url":"https://www.yelp.com/biz/x?key=live-key"}`)),
+			Request:    req,
+		}, nil
+	})
+	rc := &yelptest.Recorder{Dir: dir, Secrets: []string{"live-key"}, Transport: live}
+	c, err := yelp.New(&http.Client{Transport: rc}, "live-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.BusinessByID(context.Background(), garyDanko, yelp.BusinessOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("recorded %v (%v), want one fixture", files, err)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "live-key") {
+		t.Fatalf("fixture contains the secret:\n%s", data)
+	}
+
+	c, err = yelp.New(&http.Client{Transport: &yelptest.Replayer{Dir: dir}}, "fixture-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := c.BusinessByID(context.Background(), garyDanko, yelp.BusinessOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "https://www.yelp.com/biz/x?key=REDACTED"; b.URL != want {
+		t.Fatalf("replayed URL %q, want %q", b.URL, want)
+	}
+}